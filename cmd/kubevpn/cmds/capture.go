@@ -0,0 +1,87 @@
+package cmds
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/wencaiwulue/kubevpn/pkg/core"
+	"github.com/wencaiwulue/kubevpn/pkg/daemon"
+	"github.com/wencaiwulue/kubevpn/pkg/daemon/rpc"
+)
+
+func CmdCapture(f cmdutil.Factory) *cobra.Command {
+	req := &rpc.CaptureRequest{}
+	var file string
+	cmd := &cobra.Command{
+		Use:   "capture",
+		Short: i18n.T("Capture packets of tunnel as pcapng"),
+		Long: templates.LongDesc(i18n.T(`
+		Capture packets of tunnel as pcapng, which can be opened by wireshark or tcpdump.
+
+		Packets are selected by --match, it is not compiled to BPF, only a subset of pcap filter syntax is supported:
+		[src|dst] host <ip>, [src|dst] net <cidr>, [src|dst] port <port>, ip, ip6, tcp, udp, icmp, icmp6,
+		combined with and, or, not and parentheses. other primitives like len, portrange or byte offsets are rejected.
+
+		With --remote, packets of every running traffic manager pod are merged, each pod is one interface of pcapng.
+		`)),
+		Example: templates.Examples(i18n.T(`
+        # capture packets between local tun device and traffic manager
+        kubevpn capture -w out.pcapng
+
+        # capture http traffic of one pod
+        kubevpn capture --match "host 10.244.0.12 and tcp port 80" -w out.pcapng
+
+        # capture packets routed by traffic manager and show them with wireshark
+        kubevpn capture --remote | wireshark -k -i -
+`)),
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			if _, err = core.ParseCaptureFilter(req.Filter); err != nil {
+				return err
+			}
+			// startup daemon process and sudo process
+			return daemon.StartupDaemon(cmd.Context())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var out io.Writer = os.Stdout
+			if file != "" && file != "-" {
+				w, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer w.Close()
+				out = w
+			}
+			client, err := daemon.GetClient(true).Capture(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			var resp *rpc.CaptureResponse
+			for {
+				resp, err = client.Recv()
+				if err == io.EOF {
+					break
+				} else if err == nil {
+					if _, err = out.Write(resp.Data); err != nil {
+						return err
+					}
+				} else if code := status.Code(err); code == codes.DeadlineExceeded || code == codes.Canceled {
+					return nil
+				} else {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Filter, "match", "", "Only capture packets which match expression, a subset of pcap filter syntax instead of BPF, eg: \"host 10.244.0.12 and tcp port 80\"")
+	cmd.Flags().StringVarP(&file, "write", "w", "-", "Write pcapng to file, write to stdout if it is -")
+	cmd.Flags().BoolVar(&req.Remote, "remote", false, "Capture packets routed by traffic manager instead of local tunnel")
+	return cmd
+}
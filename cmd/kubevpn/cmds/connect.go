@@ -58,6 +58,12 @@ func CmdConnect(f cmdutil.Factory) *cobra.Command {
 				ExtraDomain:          connect.ExtraDomain,
 				UseLocalDNS:          connect.UseLocalDNS,
 				Engine:               string(connect.Engine),
+				Streams:              connect.Streams,
+				MTU:                  connect.MTU,
+				Transport:            string(connect.Transport),
+				TransportAddr:        connect.TransportAddr,
+				PrimaryDNS:           connect.PrimaryDNS,
+				Replicas:             connect.Replicas,
 				OriginKubeconfigPath: util.GetKubeconfigPath(f),
 
 				SshJump:       sshConf.ToRPC(),
@@ -113,9 +119,15 @@ func CmdConnect(f cmdutil.Factory) *cobra.Command {
 	cmd.Flags().StringArrayVar(&connect.ExtraDomain, "extra-domain", []string{}, "Extra domain string, the resolved ip will add to route table, eg: --extra-domain test.abc.com --extra-domain foo.test.com")
 	cmd.Flags().BoolVar(&transferImage, "transfer-image", false, "transfer image to remote registry, it will transfer image "+config.OriginImage+" to flags `--image` special image, default: "+config.Image)
 	cmd.Flags().BoolVar(&connect.UseLocalDNS, "use-localdns", false, "if use-lcoaldns is true, kubevpn will start coredns listen at 53 to forward your dns queries. only support on linux now")
+	cmd.Flags().Int32Var(&connect.Streams, "streams", 1, "Count of tcp streams over port-forward, more than 1 means multiplexed transport, packets of one flow go through one stream, one stalled stream will not block others, better for high-latency api-server, eg: --streams 4")
+	cmd.Flags().Int32Var(&connect.Replicas, "replicas", config.DefaultReplicas, "Replicas of traffic manager if it not exists, routes of clients are shared between replicas, so clients connect to any of them, eg: --replicas 3")
+	cmd.Flags().Int32Var(&connect.MTU, "mtu", 0, fmt.Sprintf("MTU of tun device, if not special, use %d and probe path mtu through tunnel, packets larger than path mtu will be rejected by icmp fragmentation needed, eg: --mtu 1350", config.DefaultMTU))
+	cmd.Flags().StringVar((*string)(&connect.Transport), "transport", string(config.TransportPortForward), fmt.Sprintf("How to reach traffic manager, %s, %s (websocket through ingress which routes to service %s port 10805) or %s (http CONNECT through HTTPS_PROXY to exposed port 10800, 10801 and 10802 of traffic manager), use %s or %s if port-forward is blocked by proxy", config.TransportPortForward, config.TransportWebSocket, config.ConfigMapPodTrafficManager, config.TransportHTTPConnect, config.TransportWebSocket, config.TransportHTTPConnect))
+	cmd.Flags().StringVar(&connect.TransportAddr, "transport-addr", "", "Address of traffic manager for transport ws or connect, eg: --transport ws --transport-addr wss://kubevpn.example.com/tunnel, --transport connect --transport-addr kubevpn.example.com:10800")
 	cmd.Flags().StringVar((*string)(&connect.Engine), "engine", string(config.EngineRaw), fmt.Sprintf(`transport engine ("%s"|"%s") %s: use gvisor and raw both (both performance and stable), %s: use raw mode (best stable)`, config.EngineMix, config.EngineRaw, config.EngineMix, config.EngineRaw))
 	cmd.Flags().BoolVar(&foreground, "foreground", false, "Hang up")
 	cmd.Flags().BoolVar(&lite, "lite", false, "connect to multiple cluster in lite mode, you needs to special this options")
+	cmd.Flags().BoolVar(&connect.PrimaryDNS, "primary-dns", false, "Resolve unqualified names like productpage, productpage.default by this cluster in lite mode, names like productpage.default.svc.<kubeconfig-context> are always resolved by cluster of that context")
 
 	addSshFlags(cmd, sshConf)
 	return cmd
@@ -10,7 +10,7 @@ import (
 	"github.com/wencaiwulue/kubevpn/pkg/util"
 )
 
-func CmdControlPlane(_ cmdutil.Factory) *cobra.Command {
+func CmdControlPlane(f cmdutil.Factory) *cobra.Command {
 	var (
 		watchDirectoryFilename string
 		port                   uint = 9002
@@ -23,10 +23,19 @@ func CmdControlPlane(_ cmdutil.Factory) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			util.InitLogger(config.Debug)
 			go util.StartupPProf(0)
-			controlplane.Main(watchDirectoryFilename, port, log.StandardLogger())
+			clientset, err := f.KubernetesClientSet()
+			if err != nil {
+				log.Warnf("failed to create kubernetes clientset, err: %v", err)
+			}
+			client, err := f.DynamicClient()
+			if err != nil {
+				log.Warnf("failed to create kubernetes dynamic client, err: %v", err)
+			}
+			namespace, _, _ := f.ToRawKubeConfigLoader().Namespace()
+			controlplane.Main(watchDirectoryFilename, port, log.StandardLogger(), clientset, client, namespace)
 		},
 	}
-	cmd.Flags().StringVarP(&watchDirectoryFilename, "watchDirectoryFilename", "w", "/etc/envoy/envoy-config.yaml", "full path to directory to watch for files")
+	cmd.Flags().StringVarP(&watchDirectoryFilename, "watchDirectoryFilename", "w", "", "full path to envoy config file to watch, if empty, watch configmap "+config.ConfigMapPodTrafficManager+" directly")
 	cmd.Flags().BoolVar(&config.Debug, "debug", false, "true/false")
 	return cmd
 }
@@ -46,6 +46,30 @@ func CmdProxy(f cmdutil.Factory) *cobra.Command {
 		# Reverse proxy with mesh, traffic with header a=1, will hit local PC, otherwise no effect
 		kubevpn proxy service/productpage --headers a=1
 
+		# Reverse proxy with mesh, support regex, prefix, present match and request path, method, query parameter
+		kubevpn proxy service/productpage --headers x-user=~^qa- --headers :path=/api/v2/* --headers :method=GET --headers ?version=v2
+
+		# Reverse proxy with mesh, 10% of traffic will hit local PC, the rest no effect
+		kubevpn proxy service/productpage --weight 10
+
+		# Mirror traffic with mesh, workloads still serve the traffic, a copy of request will hit local PC
+		kubevpn proxy service/productpage --mirror
+		or mirror traffic with header a=1 only
+		kubevpn proxy service/productpage --mirror --headers a=1
+
+		# Reverse proxy with mesh, if local PC is unreachable (eg: local service is stopped), fall back to origin workloads
+		kubevpn proxy service/productpage --fallback
+		or fall back traffic with header a=1 only
+		kubevpn proxy service/productpage --fallback --headers a=1
+
+		# Reverse proxy grpc service with mesh, match grpc method and metadata
+		kubevpn proxy service/productpage --headers :grpc=pkg.Service/Method --headers x-user=naison
+
+		# Reverse proxy non-http traffic (raw tcp or tls) with mesh, match source pod labels, source ip or tls server name (sni)
+		kubevpn proxy deployment/mysql --source-labels app=reviews
+		kubevpn proxy deployment/mysql --source-ips 10.0.0.0/24
+		kubevpn proxy deployment/productpage --server-names productpage.example.com
+
 		# Connect to api-server behind of bastion host or ssh jump host and proxy kubernetes resource traffic into local PC
 		kubevpn proxy deployment/productpage --ssh-addr 192.168.1.100:22 --ssh-username root --ssh-keyfile ~/.ssh/ssh.pem --headers a=1
 
@@ -89,11 +113,22 @@ func CmdProxy(f cmdutil.Factory) *cobra.Command {
 					KubeconfigBytes:      string(bytes),
 					Namespace:            ns,
 					Headers:              connect.Headers,
+					Weight:               connect.Weight,
+					Mirror:               connect.Mirror,
+					Fallback:             connect.Fallback,
+					SourceLabels:         connect.SourceLabels,
+					SourceIPs:            connect.SourceIPs,
+					ServerNames:          connect.ServerNames,
 					Workloads:            args,
 					ExtraCIDR:            connect.ExtraCIDR,
 					ExtraDomain:          connect.ExtraDomain,
 					UseLocalDNS:          connect.UseLocalDNS,
 					Engine:               string(connect.Engine),
+					Streams:              connect.Streams,
+					MTU:                  connect.MTU,
+					Transport:            string(connect.Transport),
+					TransportAddr:        connect.TransportAddr,
+					Replicas:             connect.Replicas,
 					SshJump:              sshConf.ToRPC(),
 					TransferImage:        transferImage,
 					Image:                config.Image,
@@ -142,12 +177,23 @@ func CmdProxy(f cmdutil.Factory) *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().StringToStringVarP(&connect.Headers, "headers", "H", map[string]string{}, "Traffic with special headers with reverse it to local PC, you should startup your service after reverse workloads successfully, If not special, redirect all traffic to local PC, format is k=v, like: k1=v1,k2=v2")
+	cmd.Flags().StringToStringVarP(&connect.Headers, "headers", "H", map[string]string{}, "Traffic with special headers with reverse it to local PC, you should startup your service after reverse workloads successfully, If not special, redirect all traffic to local PC, format is k=v, like: k1=v1,k2=v2. value ~regex means regex match, it matches part of value unless anchored by ^ or $, prefix* means prefix match, * means present, special key :path, :method, :grpc and ?query-param match request path, method, grpc method and query parameter, like: x-user=~^qa-,:path=/api/v2/*. pairs in one flag are split by comma, so pass value which contains comma by its own flag, like: -H x-user=~^qa-[0-9]{1,3}$")
+	cmd.Flags().Uint32Var(&connect.Weight, "weight", 0, "Percentage of traffic which not match headers reverse it to local PC, range [0, 100], eg: --weight 10 means 10% traffic hit local PC, the rest still go to origin workloads")
+	cmd.Flags().BoolVar(&connect.Mirror, "mirror", false, "Mirror traffic to local PC, workloads still serve the traffic, but shadow a copy of request to local PC, response of local PC is discarded, use with --headers to mirror special traffic only")
+	cmd.Flags().BoolVar(&connect.Fallback, "fallback", false, "Health check local PC, if local PC is unreachable, traffic fall back to origin workloads, only works in mesh mode, use alone means proxy all traffic with fallback. Note: origin service must listen on loopback address, like 0.0.0.0")
+	cmd.Flags().StringToStringVar(&connect.SourceLabels, "source-labels", map[string]string{}, "Non-http connection (raw tcp or tls) from pods with special labels reverse it to local PC, format is k=v, like: app=reviews,version=v1")
+	cmd.Flags().StringArrayVar(&connect.SourceIPs, "source-ips", []string{}, "Non-http connection (raw tcp or tls) from special ip or cidr reverse it to local PC, eg: --source-ips 10.0.0.1 --source-ips 10.0.1.0/24")
+	cmd.Flags().StringArrayVar(&connect.ServerNames, "server-names", []string{}, "Tls connection with special server name (sni) reverse it to local PC, support wildcard like *.example.com, eg: --server-names foo.example.com")
 	cmd.Flags().BoolVar(&config.Debug, "debug", false, "Enable debug mode or not, true or false")
 	cmd.Flags().StringVar(&config.Image, "image", config.Image, "Use this image to startup container")
 	cmd.Flags().StringArrayVar(&connect.ExtraCIDR, "extra-cidr", []string{}, "Extra cidr string, eg: --extra-cidr 192.168.0.159/24 --extra-cidr 192.168.1.160/32")
 	cmd.Flags().StringArrayVar(&connect.ExtraDomain, "extra-domain", []string{}, "Extra domain string, the resolved ip will add to route table, eg: --extra-domain test.abc.com --extra-domain foo.test.com")
 	cmd.Flags().BoolVar(&transferImage, "transfer-image", false, "transfer image to remote registry, it will transfer image "+config.OriginImage+" to flags `--image` special image, default: "+config.Image)
+	cmd.Flags().Int32Var(&connect.Streams, "streams", 1, "Count of tcp streams over port-forward, more than 1 means multiplexed transport, packets of one flow go through one stream, one stalled stream will not block others, better for high-latency api-server, eg: --streams 4")
+	cmd.Flags().Int32Var(&connect.Replicas, "replicas", config.DefaultReplicas, "Replicas of traffic manager if it not exists, routes of clients are shared between replicas, so clients connect to any of them, eg: --replicas 3")
+	cmd.Flags().Int32Var(&connect.MTU, "mtu", 0, fmt.Sprintf("MTU of tun device, if not special, use %d and probe path mtu through tunnel, packets larger than path mtu will be rejected by icmp fragmentation needed, eg: --mtu 1350", config.DefaultMTU))
+	cmd.Flags().StringVar((*string)(&connect.Transport), "transport", string(config.TransportPortForward), fmt.Sprintf("How to reach traffic manager, %s, %s (websocket through ingress which routes to service %s port 10805) or %s (http CONNECT through HTTPS_PROXY to exposed port 10800, 10801 and 10802 of traffic manager), use %s or %s if port-forward is blocked by proxy", config.TransportPortForward, config.TransportWebSocket, config.ConfigMapPodTrafficManager, config.TransportHTTPConnect, config.TransportWebSocket, config.TransportHTTPConnect))
+	cmd.Flags().StringVar(&connect.TransportAddr, "transport-addr", "", "Address of traffic manager for transport ws or connect, eg: --transport ws --transport-addr wss://kubevpn.example.com/tunnel, --transport connect --transport-addr kubevpn.example.com:10800")
 	cmd.Flags().StringVar((*string)(&connect.Engine), "engine", string(config.EngineRaw), fmt.Sprintf(`transport engine ("%s"|"%s") %s: use gvisor and raw both (both performance and stable), %s: use raw mode (best stable)`, config.EngineMix, config.EngineRaw, config.EngineMix, config.EngineRaw))
 	cmd.Flags().BoolVar(&foreground, "foreground", false, "foreground hang up")
 
@@ -73,6 +73,7 @@ func NewKubeVPNCommand() *cobra.Command {
 				CmdSSH(factory),
 				CmdSSHDaemon(factory),
 				CmdLogs(factory),
+				CmdCapture(factory),
 				CmdReset(factory),
 				CmdQuit(factory),
 			},
@@ -2,6 +2,7 @@ package cmds
 
 import (
 	"math/rand"
+	"net/http"
 	"runtime"
 	"time"
 
@@ -18,8 +19,13 @@ import (
 	"github.com/wencaiwulue/kubevpn/pkg/util"
 )
 
-func CmdServe(_ cmdutil.Factory) *cobra.Command {
+func CmdServe(f cmdutil.Factory) *cobra.Command {
 	var route = &core.Route{}
+	var authenticate bool
+	var rateLimit bool
+	var shareRoutes bool
+	var metricsAddr string
+	var captureAddr string
 	cmd := &cobra.Command{
 		Use:    "serve",
 		Hidden: true,
@@ -47,17 +53,69 @@ func CmdServe(_ cmdutil.Factory) *cobra.Command {
 					log.Errorf("release ip failed: %v", err)
 				}
 			}()
+			ctx := cmd.Context()
+			if authenticate || rateLimit || shareRoutes {
+				clientset, err := f.KubernetesClientSet()
+				if err != nil {
+					return err
+				}
+				namespace, _, err := f.ToRawKubeConfigLoader().Namespace()
+				if err != nil {
+					return err
+				}
+				watcher := handler.NewTrafficManagerWatcher(clientset, namespace)
+				if authenticate {
+					if err = handler.WatchTunnelKeys(watcher); err != nil {
+						log.Errorf("watch tunnel keys failed: %v", err)
+						return err
+					}
+				}
+				if rateLimit {
+					if err = handler.WatchRateLimits(watcher); err != nil {
+						log.Errorf("watch rate limits failed: %v", err)
+						return err
+					}
+				}
+				if shareRoutes {
+					if err = handler.SharePeerRoutes(ctx, clientset, namespace, watcher); err != nil {
+						log.Errorf("share peer routes failed: %v", err)
+						return err
+					}
+				}
+				if err = watcher.Start(ctx); err != nil {
+					log.Errorf("watch traffic manager failed: %v", err)
+					return err
+				}
+			}
+			if metricsAddr != "" {
+				go func() {
+					mux := http.NewServeMux()
+					mux.Handle("/metrics", core.MetricsHandler(core.PeerStats))
+					log.Errorf("serve metrics failed: %v", http.ListenAndServe(metricsAddr, mux))
+				}()
+			}
+			if captureAddr != "" {
+				go func() {
+					mux := http.NewServeMux()
+					mux.Handle("/capture", core.CaptureHandler())
+					log.Errorf("serve capture failed: %v", http.ListenAndServe(captureAddr, mux))
+				}()
+			}
 			servers, err := handler.Parse(*route)
 			if err != nil {
 				log.Errorf("parse server failed: %v", err)
 				return err
 			}
-			ctx := cmd.Context()
 			return handler.Run(ctx, servers)
 		},
 	}
 	cmd.Flags().StringArrayVarP(&route.ServeNodes, "node", "L", []string{}, "Startup node server. eg: tcp://localhost:1080")
 	cmd.Flags().StringVarP(&route.ChainNode, "chain", "F", "", "Forward chain. eg: tcp://192.168.1.100:2345")
+	cmd.Flags().BoolVar(&authenticate, "authenticate", false, "Only accept packets sealed by tunnel key which is issued to client when renting ip, tunnel keys are saved in secret "+config.ConfigMapPodTrafficManager)
+	cmd.Flags().BoolVar(&rateLimit, "rate-limit", false, "Limit bandwidth of every client by rate limits which are saved in configmap "+config.ConfigMapPodTrafficManager+" key "+config.KeyRateLimit+`, eg: {"default": {"rate": "10Mi"}, "clients": {"223.254.0.101": {"rate": "1Mi"}}}`)
+	cmd.Flags().BoolVar(&shareRoutes, "share-routes", false, "Share routes of clients with other replicas by configmap "+config.ConfigMapPodTrafficManager+" key "+config.KeyPeerRoute+", and forward packets to clients of other replicas through peer port "+handler.PeerPort)
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Expose prometheus metrics of tunnel peers on this address, eg: :9100, disabled if empty")
+	cmd.Flags().StringVar(&captureAddr, "capture-addr", "", "Expose packet capture of tunnel peers on this address, it contains packets of all clients, so bind it to loopback and reach it by port-forward, eg: 127.0.0.1:"+handler.CapturePort+", disabled if empty")
 	cmd.Flags().BoolVar(&config.Debug, "debug", false, "Enable debug log or not")
 	return cmd
 }
@@ -3,6 +3,8 @@ package cmds
 import (
 	"fmt"
 	"os"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -14,6 +16,7 @@ import (
 )
 
 func CmdStatus(f cmdutil.Factory) *cobra.Command {
+	var showDNS bool
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: i18n.T("KubeVPN status"),
@@ -21,11 +24,23 @@ func CmdStatus(f cmdutil.Factory) *cobra.Command {
 		Example: templates.Examples(i18n.T(`
         # show status for kubevpn status
         kubevpn status
+
+        # show query counts, latencies and cache of dns
+        kubevpn status --dns
 `)),
 		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
 			return daemon.StartupDaemon(cmd.Context())
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if showDNS {
+				// dns servers are running in sudo daemon
+				resp, err := daemon.GetClient(true).DNSStats(cmd.Context(), &rpc.DNSStatsRequest{})
+				if err != nil {
+					return err
+				}
+				printDNSStats(resp)
+				return nil
+			}
 			client, err := daemon.GetClient(false).Status(
 				cmd.Context(),
 				&rpc.StatusRequest{},
@@ -37,5 +52,23 @@ func CmdStatus(f cmdutil.Factory) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&showDNS, "dns", false, "Show query counts, latencies and cache of dns")
 	return cmd
 }
+
+func printDNSStats(resp *rpc.DNSStatsResponse) {
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', 0)
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "Queries", "LocalHits", "CacheHits", "NegativeHits", "Forwards", "Failures", "AvgLatency", "MaxLatency")
+	_, _ = fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%d\t%s\t%s\n", resp.Queries, resp.LocalHits, resp.CacheHits, resp.NegativeHits, resp.Forwards, resp.Failures,
+		time.Duration(resp.AvgLatency).Round(time.Microsecond), time.Duration(resp.MaxLatency).Round(time.Microsecond))
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "Name", "Type", "Answer", "TTL", "Hits")
+	for _, entry := range resp.Cache {
+		answer := entry.Answer
+		if entry.Negative {
+			answer = "NXDOMAIN"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", entry.Name, entry.Type, answer, time.Duration(entry.TTL)*time.Second, entry.Hits)
+	}
+	_ = w.Flush()
+}
@@ -20,20 +20,32 @@ const (
 	KeyEnvoy            = "ENVOY_CONFIG"
 	KeyClusterIPv4POOLS = "IPv4_POOLS"
 	KeyRefCount         = "REF_COUNT"
+	// KeyTrafficRule control plane set it once watching crd TrafficRule, client save proxy rules as TrafficRule
+	KeyTrafficRule = "TRAFFIC_RULE"
+	// KeyTunnelKeyClaim client claim tunnel key by saving hash of nonce as TUNNEL_KEY_CLAIM.<tun ipv4>,
+	// traffic manager only issue key to client which can write configmap
+	KeyTunnelKeyClaim = "TUNNEL_KEY_CLAIM"
+	// KeyRateLimit per client bandwidth limits, cluster admin set it to cap clients
+	KeyRateLimit = "RATE_LIMIT"
+	// KeyPeerRoute routes of clients to replica of traffic manager which they connect to
+	KeyPeerRoute = "PEER_ROUTE"
+	// KeyRuleLease lease of proxy rules in envoy config is saved as RULE_LEASE.<tun ipv4>, client renews it without
+	// rewriting envoy config
+	KeyRuleLease = "RULE_LEASE"
 
 	// secret keys
 	// TLSCertKey is the key for tls certificates in a TLS secret.
 	TLSCertKey = "tls_crt"
 	// TLSPrivateKeyKey is the key for the private key field in a TLS secret.
 	TLSPrivateKeyKey = "tls_key"
+	// KeyTunnelKey tunnel keys of clients are saved as TUNNEL_KEY.<tun ipv4>, only traffic manager issue them
+	KeyTunnelKey = "TUNNEL_KEY"
 
 	// container name
 	ContainerSidecarEnvoyProxy   = "envoy-proxy"
 	ContainerSidecarControlPlane = "control-plane"
 	ContainerSidecarVPN          = "vpn"
 
-	VolumeEnvoyConfig = "envoy-config"
-
 	innerIPv4Pool = "223.254.0.100/16"
 	// 原因：在docker环境中，设置docker的 gateway 和 subnet，不能 inner 的冲突，也不能和 docker的 172.17 冲突
 	// 不然的话，请求会不通的
@@ -64,18 +76,23 @@ const (
 	// env name
 	EnvInboundPodTunIPv4 = "TunIPv4"
 	EnvInboundPodTunIPv6 = "TunIPv6"
+	EnvTunnelKey         = "TunnelKey"
 	EnvPodName           = "POD_NAME"
 	EnvPodNamespace      = "POD_NAMESPACE"
+	EnvPodIP             = "POD_IP"
 
 	// header name
-	HeaderPodName      = "POD_NAME"
-	HeaderPodNamespace = "POD_NAMESPACE"
-	HeaderIPv4         = "IPv4"
-	HeaderIPv6         = "IPv6"
+	HeaderPodName        = "POD_NAME"
+	HeaderPodNamespace   = "POD_NAMESPACE"
+	HeaderIPv4           = "IPv4"
+	HeaderIPv6           = "IPv6"
+	HeaderTunnelKeyClaim = "TUNNEL_KEY_CLAIM"
+	HeaderTunnelKeyProof = "TUNNEL_KEY_PROOF"
 
 	// api
 	APIRentIP    = "/rent/ip"
 	APIReleaseIP = "/release/ip"
+	APITunnelKey = "/tunnel/key"
 
 	KUBECONFIG = "kubeconfig"
 
@@ -152,6 +169,9 @@ var (
 	DefaultMTU = 1500 - 20 - 8 - 21
 )
 
+// DefaultReplicas replicas of traffic manager, routes of clients are shared between replicas if it is more than one
+const DefaultReplicas = 1
+
 var (
 	LPool = &sync.Pool{
 		New: func() interface{} {
@@ -173,3 +193,12 @@ const (
 	EngineMix    Engine = "mix"
 	EngineRaw    Engine = "raw"
 )
+
+// Transport how client reaches traffic manager
+type Transport string
+
+const (
+	TransportPortForward Transport = "port-forward"
+	TransportWebSocket   Transport = "ws"
+	TransportHTTPConnect Transport = "connect"
+)
@@ -2,6 +2,8 @@ package controlplane
 
 import (
 	"fmt"
+	"net"
+	"sort"
 	"time"
 
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -14,10 +16,11 @@ import (
 	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	httpinspector "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/http_inspector/v3"
 	dstv3inspector "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/original_dst/v3"
+	tlsinspector "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	httpconnectionmanager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	tcpproxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	httpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
-	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -26,6 +29,9 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
 )
 
 type Virtual struct {
@@ -38,6 +44,59 @@ type Rule struct {
 	Headers      map[string]string
 	LocalTunIPv4 string
 	LocalTunIPv6 string
+	// Weight percentage of traffic which not match any headers will be routed to local tun ip, range [0, 100]
+	Weight uint32
+	// Mirror traffic still served by origin cluster, but shadow a copy to local tun ip, response of local is discarded
+	Mirror bool
+	// Fallback health check local tun ip, if local is unreachable, traffic fail over to origin workloads
+	Fallback bool
+
+	// SourceLabels, SourceIPs and ServerNames route non-http (raw tcp) connection, which comes from pods with labels,
+	// comes from source ip (cidr) or tls sni in server names, to local tun ip.
+	// SourceLabels will be resolved to pod ip by control plane
+	SourceLabels map[string]string
+	SourceIPs    []string
+	ServerNames  []string
+
+	// ExpireAt lease of rule, renewed by client heartbeat, control plane ignore expired rule, so if client is gone
+	// without leave, traffic will fall back to origin workloads. nil means never expire. rules in configmap take it
+	// from key RULE_LEASE.<tun ipv4>, so renewing it not rewrite envoy config
+	ExpireAt *metav1.Time
+}
+
+// RuleLeaseDuration client renew rule lease every RuleLeaseDuration/4
+const RuleLeaseDuration = time.Minute * 2
+
+// RuleLeaseKey configmap key of lease of rules which route to localTunIPv4
+func RuleLeaseKey(localTunIPv4 string) string {
+	return fmt.Sprintf("%s.%s", config.KeyRuleLease, localTunIPv4)
+}
+
+// ApplyRuleLeases set lease of rules from configmap data, rules which has no lease in it keep their own
+func ApplyRuleLeases(configList []*Virtual, data map[string]string) {
+	for _, virtual := range configList {
+		for _, rule := range virtual.Rules {
+			value, ok := data[RuleLeaseKey(rule.LocalTunIPv4)]
+			if !ok {
+				continue
+			}
+			expireAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				continue
+			}
+			rule.ExpireAt = &metav1.Time{Time: expireAt}
+		}
+	}
+}
+
+// IsExpired rule lease is expired or not
+func (r *Rule) IsExpired(now time.Time) bool {
+	return r.ExpireAt != nil && r.ExpireAt.Time.Before(now)
+}
+
+// IsTCP rule route non-http connection or not
+func (r *Rule) IsTCP() bool {
+	return len(r.SourceLabels) != 0 || len(r.SourceIPs) != 0 || len(r.ServerNames) != 0
 }
 
 func (a *Virtual) To() (
@@ -48,66 +107,127 @@ func (a *Virtual) To() (
 ) {
 	//clusters = append(clusters, OriginCluster())
 	for _, port := range a.Ports {
-		listenerName := fmt.Sprintf("%s_%v_%s", a.Uid, port.ContainerPort, port.Protocol)
-		routeName := listenerName
-		listeners = append(listeners, ToListener(listenerName, routeName, port.ContainerPort, port.Protocol))
+		// envoy hands off the redirected connection to the listener which address matches the original
+		// destination, so ipv4 and ipv6 traffic needs separate listeners, each one route to same family tun ip.
+		// listener must not depend on http rules, http rules only change route and cluster, if listener changed,
+		// envoy will drain it and break long-lived stream, like grpc bidi stream. tcp rules add filter chain to
+		// listener, envoy only drain the changed filter chain
+		for _, ipv6 := range []bool{false, true} {
+			listenerName := fmt.Sprintf("%s_%v_%s", a.Uid, port.ContainerPort, port.Protocol)
+			if ipv6 {
+				listenerName = fmt.Sprintf("%s_%s", listenerName, "ipv6")
+			}
+			routeName := listenerName
 
-		var rr []*route.Route
-		for _, rule := range a.Rules {
-			for _, ip := range []string{rule.LocalTunIPv4, rule.LocalTunIPv6} {
+			var rr []*route.Route
+			var weights = make(map[string]uint32)
+			var mirrors []string
+			var chains []*listener.FilterChain
+			var chainKeys = make(map[string]bool)
+			for _, rule := range a.Rules {
+				ip := rule.LocalTunIPv4
+				if ipv6 {
+					ip = rule.LocalTunIPv6
+				}
+				if len(ip) == 0 {
+					continue
+				}
 				clusterName := fmt.Sprintf("%s_%v", ip, port.ContainerPort)
-				clusters = append(clusters, ToCluster(clusterName))
-				endpoints = append(endpoints, ToEndPoint(clusterName, ip, port.ContainerPort))
-				rr = append(rr, ToRoute(clusterName, rule.Headers))
+				clusters = append(clusters, ToCluster(clusterName, rule.Fallback))
+				endpoints = append(endpoints, ToEndPoint(clusterName, ip, port.ContainerPort, rule.Fallback))
+				switch {
+				case rule.Mirror && len(rule.Headers) != 0:
+					rr = append(rr, ToMirrorRoute(clusterName, rule.Headers))
+				case rule.Mirror:
+					mirrors = append(mirrors, clusterName)
+				case len(rule.Headers) != 0:
+					rr = append(rr, ToRoute(clusterName, rule.Headers))
+				}
+				if rule.Weight != 0 && !rule.Mirror {
+					weights[clusterName] = rule.Weight
+				}
+				// envoy reject listener which has multiple filter chains with same match, first one wins
+				if rule.IsTCP() && (len(rule.SourceIPs) != 0 || len(rule.ServerNames) != 0) {
+					key := fmt.Sprintf("%v/%v", rule.SourceIPs, rule.ServerNames)
+					if !chainKeys[key] {
+						chainKeys[key] = true
+						chains = append(chains, ToTCPFilterChain(clusterName, rule.SourceIPs, rule.ServerNames))
+					}
+				}
+			}
+			listeners = append(listeners, ToListener(listenerName, routeName, port.ContainerPort, port.Protocol, ipv6, chains))
+			var defaultRoute *route.Route
+			if len(weights) != 0 {
+				defaultRoute = ToWeightedRoute(weights)
+			} else {
+				defaultRoute = DefaultRoute()
 			}
+			defaultRoute.GetRoute().RequestMirrorPolicies = ToMirrorPolicies(mirrors)
+			rr = append(rr, defaultRoute)
+			routes = append(routes, &route.RouteConfiguration{
+				Name: routeName,
+				VirtualHosts: []*route.VirtualHost{
+					{
+						Name:    "local_service",
+						Domains: []string{"*"},
+						Routes:  rr,
+					},
+				},
+				MaxDirectResponseBodySizeBytes: nil,
+			})
 		}
-		rr = append(rr, DefaultRoute())
 		clusters = append(clusters, OriginCluster())
-		routes = append(routes, &route.RouteConfiguration{
-			Name: routeName,
-			VirtualHosts: []*route.VirtualHost{
-				{
-					Name:    "local_service",
-					Domains: []string{"*"},
-					Routes:  rr,
-				},
-			},
-			MaxDirectResponseBodySizeBytes: nil,
-		})
 	}
 	return
 }
 
-func ToEndPoint(clusterName string, localTunIP string, port int32) *endpoint.ClusterLoadAssignment {
-	return &endpoint.ClusterLoadAssignment{
-		ClusterName: clusterName,
-		Endpoints: []*endpoint.LocalityLbEndpoints{{
-			LbEndpoints: []*endpoint.LbEndpoint{{
-				HostIdentifier: &endpoint.LbEndpoint_Endpoint{
-					Endpoint: &endpoint.Endpoint{
-						Address: &core.Address{
-							Address: &core.Address_SocketAddress{
-								SocketAddress: &core.SocketAddress{
-									Address: localTunIP,
-									PortSpecifier: &core.SocketAddress_PortValue{
-										PortValue: uint32(port),
-									},
+// ToEndPoint local tun ip is the only endpoint, if fallback, add loopback with lower priority, envoy shares network
+// namespace with origin container and outbound traffic of envoy is not redirected, so loopback is origin workloads.
+// envoy fail over to lower priority when local tun ip is unhealthy
+func ToEndPoint(clusterName string, localTunIP string, port int32, fallback bool) *endpoint.ClusterLoadAssignment {
+	toLbEndpoint := func(ip string) *endpoint.LbEndpoint {
+		return &endpoint.LbEndpoint{
+			HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+				Endpoint: &endpoint.Endpoint{
+					Address: &core.Address{
+						Address: &core.Address_SocketAddress{
+							SocketAddress: &core.SocketAddress{
+								Address: ip,
+								PortSpecifier: &core.SocketAddress_PortValue{
+									PortValue: uint32(port),
 								},
 							},
 						},
 					},
 				},
-			}},
-		}},
+			},
+		}
+	}
+	endpoints := []*endpoint.LocalityLbEndpoints{{
+		LbEndpoints: []*endpoint.LbEndpoint{toLbEndpoint(localTunIP)},
+	}}
+	if fallback {
+		loopback := net.IPv6loopback.String()
+		if ip := net.ParseIP(localTunIP); ip != nil && ip.To4() != nil {
+			loopback = "127.0.0.1"
+		}
+		endpoints = append(endpoints, &endpoint.LocalityLbEndpoints{
+			LbEndpoints: []*endpoint.LbEndpoint{toLbEndpoint(loopback)},
+			Priority:    1,
+		})
+	}
+	return &endpoint.ClusterLoadAssignment{
+		ClusterName: clusterName,
+		Endpoints:   endpoints,
 	}
 }
 
-func ToCluster(clusterName string) *cluster.Cluster {
+func ToCluster(clusterName string, fallback bool) *cluster.Cluster {
 	anyFunc := func(m proto.Message) *anypb.Any {
 		pbst, _ := anypb.New(m)
 		return pbst
 	}
-	return &cluster.Cluster{
+	c := &cluster.Cluster{
 		Name:                 clusterName,
 		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_EDS},
 		EdsClusterConfig: &cluster.Cluster_EdsClusterConfig{
@@ -123,12 +243,39 @@ func ToCluster(clusterName string) *cluster.Cluster {
 		TypedExtensionProtocolOptions: map[string]*anypb.Any{
 			"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": anyFunc(&httpv3.HttpProtocolOptions{
 				UpstreamProtocolOptions: &httpv3.HttpProtocolOptions_UseDownstreamProtocolConfig{
-					UseDownstreamProtocolConfig: &httpv3.HttpProtocolOptions_UseDownstreamHttpConfig{},
+					UseDownstreamProtocolConfig: &httpv3.HttpProtocolOptions_UseDownstreamHttpConfig{
+						// grpc request use http2, keep it http2 to upstream
+						Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+					},
 				},
 			}),
 		},
 		DnsLookupFamily: cluster.Cluster_ALL,
 	}
+	if fallback {
+		// active tcp health check detect local process is stopped, outlier detection eject it on connect failure
+		// before next health check
+		c.HealthChecks = []*core.HealthCheck{{
+			Timeout:            durationpb.New(time.Second),
+			Interval:           durationpb.New(time.Second * 3),
+			UnhealthyThreshold: wrapperspb.UInt32(2),
+			HealthyThreshold:   wrapperspb.UInt32(1),
+			HealthChecker: &core.HealthCheck_TcpHealthCheck_{
+				TcpHealthCheck: &core.HealthCheck_TcpHealthCheck{},
+			},
+		}}
+		c.OutlierDetection = &cluster.OutlierDetection{
+			ConsecutiveGatewayFailure:          wrapperspb.UInt32(2),
+			EnforcingConsecutiveGatewayFailure: wrapperspb.UInt32(100),
+			BaseEjectionTime:                   durationpb.New(time.Second * 10),
+			MaxEjectionPercent:                 wrapperspb.UInt32(100),
+		}
+		// all traffic goes to lower priority if local is unhealthy, never panic route to unhealthy local
+		c.CommonLbConfig = &cluster.Cluster_CommonLbConfig{
+			HealthyPanicThreshold: &typev3.Percent{Value: 0},
+		}
+	}
+	return c
 }
 
 func OriginCluster() *cluster.Cluster {
@@ -146,7 +293,10 @@ func OriginCluster() *cluster.Cluster {
 		TypedExtensionProtocolOptions: map[string]*anypb.Any{
 			"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": anyFunc(&httpv3.HttpProtocolOptions{
 				UpstreamProtocolOptions: &httpv3.HttpProtocolOptions_UseDownstreamProtocolConfig{
-					UseDownstreamProtocolConfig: &httpv3.HttpProtocolOptions_UseDownstreamHttpConfig{},
+					UseDownstreamProtocolConfig: &httpv3.HttpProtocolOptions_UseDownstreamHttpConfig{
+						// grpc request use http2, keep it http2 to upstream
+						Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+					},
 				},
 			}),
 		},
@@ -154,31 +304,35 @@ func OriginCluster() *cluster.Cluster {
 }
 
 func ToRoute(clusterName string, headers map[string]string) *route.Route {
-	var r []*route.HeaderMatcher
-	for k, v := range headers {
-		r = append(r, &route.HeaderMatcher{
-			Name: k,
-			HeaderMatchSpecifier: &route.HeaderMatcher_StringMatch{
-				StringMatch: &matcher.StringMatcher{
-					MatchPattern: &matcher.StringMatcher_Exact{
-						Exact: v,
-					},
-					IgnoreCase: true,
+	return &route.Route{
+		Match: ToRouteMatch(headers),
+		Action: &route.Route_Route{
+			Route: &route.RouteAction{
+				ClusterSpecifier: &route.RouteAction_Cluster{
+					Cluster: clusterName,
+				},
+				Timeout:     durationpb.New(0),
+				IdleTimeout: durationpb.New(0),
+				MaxStreamDuration: &route.RouteAction_MaxStreamDuration{
+					MaxStreamDuration:    durationpb.New(0),
+					GrpcTimeoutHeaderMax: durationpb.New(0),
 				},
 			},
-		})
+		},
 	}
+}
+
+func DefaultRoute() *route.Route {
 	return &route.Route{
 		Match: &route.RouteMatch{
 			PathSpecifier: &route.RouteMatch_Prefix{
 				Prefix: "/",
 			},
-			Headers: r,
 		},
 		Action: &route.Route_Route{
 			Route: &route.RouteAction{
 				ClusterSpecifier: &route.RouteAction_Cluster{
-					Cluster: clusterName,
+					Cluster: "origin_cluster",
 				},
 				Timeout:     durationpb.New(0),
 				IdleTimeout: durationpb.New(0),
@@ -191,7 +345,51 @@ func ToRoute(clusterName string, headers map[string]string) *route.Route {
 	}
 }
 
-func DefaultRoute() *route.Route {
+// ToMirrorRoute traffic match headers still route to origin cluster, and shadow a copy to local tun ip cluster
+func ToMirrorRoute(clusterName string, headers map[string]string) *route.Route {
+	r := DefaultRoute()
+	r.Match = ToRouteMatch(headers)
+	r.GetRoute().RequestMirrorPolicies = ToMirrorPolicies([]string{clusterName})
+	return r
+}
+
+// ToMirrorPolicies shadow all request to clusters, envoy will fire and forget, the response is discarded
+func ToMirrorPolicies(clusterNames []string) []*route.RouteAction_RequestMirrorPolicy {
+	var policies []*route.RouteAction_RequestMirrorPolicy
+	for _, name := range clusterNames {
+		policies = append(policies, &route.RouteAction_RequestMirrorPolicy{
+			Cluster: name,
+		})
+	}
+	return policies
+}
+
+// ToWeightedRoute split traffic to local tun ip clusters by weight (percentage), the rest goes to origin cluster.
+// if sum of weights more than 100, all traffic split between local tun ip clusters
+func ToWeightedRoute(weights map[string]uint32) *route.Route {
+	var names = make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	// keep route stable, otherwise envoy will update route every time
+	sort.Strings(names)
+
+	var total uint32
+	var clusters []*route.WeightedCluster_ClusterWeight
+	for _, name := range names {
+		total += weights[name]
+		clusters = append(clusters, &route.WeightedCluster_ClusterWeight{
+			Name:   name,
+			Weight: wrapperspb.UInt32(weights[name]),
+		})
+	}
+	if total < 100 {
+		clusters = append(clusters, &route.WeightedCluster_ClusterWeight{
+			Name:   "origin_cluster",
+			Weight: wrapperspb.UInt32(100 - total),
+		})
+		total = 100
+	}
 	return &route.Route{
 		Match: &route.RouteMatch{
 			PathSpecifier: &route.RouteMatch_Prefix{
@@ -200,8 +398,11 @@ func DefaultRoute() *route.Route {
 		},
 		Action: &route.Route_Route{
 			Route: &route.RouteAction{
-				ClusterSpecifier: &route.RouteAction_Cluster{
-					Cluster: "origin_cluster",
+				ClusterSpecifier: &route.RouteAction_WeightedClusters{
+					WeightedClusters: &route.WeightedCluster{
+						Clusters:    clusters,
+						TotalWeight: wrapperspb.UInt32(total),
+					},
 				},
 				Timeout:     durationpb.New(0),
 				IdleTimeout: durationpb.New(0),
@@ -214,7 +415,60 @@ func DefaultRoute() *route.Route {
 	}
 }
 
-func ToListener(listenerName string, routeName string, port int32, p corev1.Protocol) *listener.Listener {
+// ToTCPFilterChain route connection comes from source ip ranges or tls sni in server names to cluster
+func ToTCPFilterChain(clusterName string, sourceIPs []string, serverNames []string) *listener.FilterChain {
+	anyFunc := func(m proto.Message) *anypb.Any {
+		pbst, _ := anypb.New(m)
+		return pbst
+	}
+
+	var ranges []*core.CidrRange
+	for _, s := range sourceIPs {
+		ipNet, err := ParseCIDR(s)
+		if err != nil {
+			continue
+		}
+		ones, _ := ipNet.Mask.Size()
+		ranges = append(ranges, &core.CidrRange{
+			AddressPrefix: ipNet.IP.String(),
+			PrefixLen:     wrapperspb.UInt32(uint32(ones)),
+		})
+	}
+	return &listener.FilterChain{
+		Name: clusterName,
+		FilterChainMatch: &listener.FilterChainMatch{
+			SourcePrefixRanges: ranges,
+			ServerNames:        serverNames,
+		},
+		Filters: []*listener.Filter{
+			{
+				Name: wellknown.TCPProxy,
+				ConfigType: &listener.Filter_TypedConfig{
+					TypedConfig: anyFunc(&tcpproxy.TcpProxy{
+						StatPrefix: "tcp",
+						ClusterSpecifier: &tcpproxy.TcpProxy_Cluster{
+							Cluster: clusterName,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// ParseCIDR parse cidr or single ip
+func ParseCIDR(s string) (*net.IPNet, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	return ipNet, err
+}
+
+func ToListener(listenerName string, routeName string, port int32, p corev1.Protocol, ipv6 bool, tcpChains []*listener.FilterChain) *listener.Listener {
 	var protocol core.SocketAddress_Protocol
 	switch p {
 	case corev1.ProtocolTCP:
@@ -225,6 +479,11 @@ func ToListener(listenerName string, routeName string, port int32, p corev1.Prot
 		protocol = core.SocketAddress_TCP
 	}
 
+	address := "0.0.0.0"
+	if ipv6 {
+		address = "::"
+	}
+
 	anyFunc := func(m proto.Message) *anypb.Any {
 		pbst, _ := anypb.New(m)
 		return pbst
@@ -297,14 +556,14 @@ func ToListener(listenerName string, routeName string, port int32, p corev1.Prot
 			Address: &core.Address_SocketAddress{
 				SocketAddress: &core.SocketAddress{
 					Protocol: protocol,
-					Address:  "0.0.0.0",
+					Address:  address,
 					PortSpecifier: &core.SocketAddress_PortValue{
 						PortValue: uint32(port),
 					},
 				},
 			},
 		},
-		FilterChains: []*listener.FilterChain{
+		FilterChains: append(tcpChains, []*listener.FilterChain{
 			{
 				FilterChainMatch: &listener.FilterChainMatch{
 					ApplicationProtocols: []string{"http/1.0", "http/1.1", "h2c"},
@@ -328,8 +587,14 @@ func ToListener(listenerName string, routeName string, port int32, p corev1.Prot
 					},
 				},
 			},
-		},
+		}...),
 		ListenerFilters: []*listener.ListenerFilter{
+			{
+				Name: wellknown.TlsInspector,
+				ConfigType: &listener.ListenerFilter_TypedConfig{
+					TypedConfig: anyFunc(&tlsinspector.TlsInspector{}),
+				},
+			},
 			{
 				Name: wellknown.HttpInspector,
 				ConfigType: &listener.ListenerFilter_TypedConfig{
@@ -0,0 +1,240 @@
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// add or remove rule should not change listener, otherwise envoy will drain it and break in-flight stream
+func TestListenerNotChangeWhenRuleChange(t *testing.T) {
+	virtual := &Virtual{
+		Uid:   "deployments.apps.productpage",
+		Ports: []corev1.ContainerPort{{ContainerPort: 9080, Protocol: corev1.ProtocolTCP}},
+		Rules: []*Rule{{
+			Headers:      map[string]string{":grpc": "pkg.Svc/Method"},
+			LocalTunIPv4: "223.254.0.100",
+			LocalTunIPv6: "efff:ffff:ffff:ffff:ffff:ffff:ffff:999a",
+		}},
+	}
+	listeners, _, routes, _ := virtual.To()
+
+	virtual.Rules = append(virtual.Rules, &Rule{
+		Headers:      map[string]string{"x-user": "naison"},
+		LocalTunIPv4: "223.254.0.101",
+		LocalTunIPv6: "efff:ffff:ffff:ffff:ffff:ffff:ffff:999b",
+	})
+	listeners2, _, routes2, _ := virtual.To()
+	virtual.Rules = nil
+	listeners3, _, _, _ := virtual.To()
+
+	if len(listeners) != 2 || len(listeners2) != 2 || len(listeners3) != 2 {
+		t.Fatalf("expect ipv4 and ipv6 listener")
+	}
+	for i := range listeners {
+		if !proto.Equal(listeners[i], listeners2[i]) || !proto.Equal(listeners[i], listeners3[i]) {
+			t.Fatalf("listener changed after rule changed")
+		}
+	}
+	if proto.Equal(routes[0], routes2[0]) {
+		t.Fatalf("route should be changed after rule changed")
+	}
+}
+
+// ipv4 and ipv6 traffic is redirected to listener of same family, and routed to tun ip of same family
+func TestDualStackListener(t *testing.T) {
+	virtual := &Virtual{
+		Uid:   "deployments.apps.productpage",
+		Ports: []corev1.ContainerPort{{ContainerPort: 9080, Protocol: corev1.ProtocolTCP}},
+		Rules: []*Rule{
+			{
+				Headers:      map[string]string{"x-user": "naison"},
+				LocalTunIPv4: "223.254.0.100",
+				LocalTunIPv6: "efff:ffff:ffff:ffff:ffff:ffff:ffff:999a",
+			},
+			{Headers: map[string]string{"x-user": "ipv4"}, LocalTunIPv4: "223.254.0.101"},
+		},
+	}
+	listeners, _, routes, _ := virtual.To()
+	if len(listeners) != 2 || len(routes) != 2 {
+		t.Fatalf("expect ipv4 and ipv6 listener and route, but got %d listeners, %d routes", len(listeners), len(routes))
+	}
+	for i, expect := range []struct {
+		name     string
+		address  string
+		clusters []string
+	}{
+		{
+			name:     "deployments.apps.productpage_9080_TCP",
+			address:  "0.0.0.0",
+			clusters: []string{"223.254.0.100_9080", "223.254.0.101_9080", "origin_cluster"},
+		},
+		{
+			name:     "deployments.apps.productpage_9080_TCP_ipv6",
+			address:  "::",
+			clusters: []string{"efff:ffff:ffff:ffff:ffff:ffff:ffff:999a_9080", "origin_cluster"},
+		},
+	} {
+		l := listeners[i].(*listener.Listener)
+		if l.Name != expect.name || l.GetAddress().GetSocketAddress().GetAddress() != expect.address {
+			t.Fatalf("expect listener %s on %s, but got %s on %s", expect.name, expect.address, l.Name, l.GetAddress().GetSocketAddress().GetAddress())
+		}
+		r := routes[i].(*route.RouteConfiguration)
+		if r.Name != expect.name {
+			t.Fatalf("expect route %s, but got %s", expect.name, r.Name)
+		}
+		rr := r.VirtualHosts[0].Routes
+		if len(rr) != len(expect.clusters) {
+			t.Fatalf("expect %d routes of %s, but got %d", len(expect.clusters), expect.name, len(rr))
+		}
+		for j, clusterName := range expect.clusters {
+			if rr[j].GetRoute().GetCluster() != clusterName {
+				t.Fatalf("expect route to %s, but got %s", clusterName, rr[j].GetRoute().GetCluster())
+			}
+		}
+	}
+}
+
+func TestToWeightedRoute(t *testing.T) {
+	r := ToWeightedRoute(map[string]uint32{"223.254.0.101_9080": 20, "223.254.0.100_9080": 10})
+	weighted := r.GetRoute().GetWeightedClusters()
+	if weighted.GetTotalWeight().GetValue() != 100 {
+		t.Fatalf("expect total weight 100, but got %d", weighted.GetTotalWeight().GetValue())
+	}
+	var expect = []struct {
+		name   string
+		weight uint32
+	}{{"223.254.0.100_9080", 10}, {"223.254.0.101_9080", 20}, {"origin_cluster", 70}}
+	if len(weighted.Clusters) != len(expect) {
+		t.Fatalf("expect %d clusters, but got %d", len(expect), len(weighted.Clusters))
+	}
+	for i, e := range expect {
+		if weighted.Clusters[i].Name != e.name || weighted.Clusters[i].Weight.GetValue() != e.weight {
+			t.Fatalf("expect cluster %s weight %d, but got %v", e.name, e.weight, weighted.Clusters[i])
+		}
+	}
+
+	r = ToWeightedRoute(map[string]uint32{"223.254.0.101_9080": 80, "223.254.0.100_9080": 70})
+	weighted = r.GetRoute().GetWeightedClusters()
+	if len(weighted.Clusters) != 2 || weighted.GetTotalWeight().GetValue() != 150 {
+		t.Fatalf("expect no origin cluster if sum of weights more than 100, but got %v", weighted)
+	}
+}
+
+func TestMirror(t *testing.T) {
+	virtual := &Virtual{
+		Uid:   "deployments.apps.productpage",
+		Ports: []corev1.ContainerPort{{ContainerPort: 9080, Protocol: corev1.ProtocolTCP}},
+		Rules: []*Rule{
+			{LocalTunIPv4: "223.254.0.100", Mirror: true},
+			{LocalTunIPv4: "223.254.0.101", Mirror: true, Headers: map[string]string{"a": "1"}},
+		},
+	}
+	_, _, routes, _ := virtual.To()
+	rr := routes[0].(*route.RouteConfiguration).VirtualHosts[0].Routes
+	if len(rr) != 2 {
+		t.Fatalf("expect 2 routes, but got %d", len(rr))
+	}
+	for i, clusterName := range []string{"223.254.0.101_9080", "223.254.0.100_9080"} {
+		action := rr[i].GetRoute()
+		if action.GetCluster() != "origin_cluster" {
+			t.Fatalf("mirror traffic should served by origin cluster, but got %s", action.GetCluster())
+		}
+		if len(action.RequestMirrorPolicies) != 1 || action.RequestMirrorPolicies[0].Cluster != clusterName {
+			t.Fatalf("expect mirror to %s, but got %v", clusterName, action.RequestMirrorPolicies)
+		}
+	}
+}
+
+func TestTCPFilterChain(t *testing.T) {
+	v := &Virtual{
+		Uid:   "deployments.authors",
+		Ports: []corev1.ContainerPort{{ContainerPort: 3306, Protocol: corev1.ProtocolTCP}},
+		Rules: []*Rule{{LocalTunIPv4: "223.254.0.100", LocalTunIPv6: "efff:ffff::100", SourceIPs: []string{"10.0.0.1", "10.1.0.0/16"}}},
+	}
+	listeners, _, _, _ := v.To()
+	l := listeners[0].(*listener.Listener)
+	if len(l.FilterChains) != 3 {
+		t.Fatalf("expect 3 filter chains, but got %d", len(l.FilterChains))
+	}
+	ranges := l.FilterChains[0].GetFilterChainMatch().GetSourcePrefixRanges()
+	if len(ranges) != 2 || ranges[0].GetPrefixLen().GetValue() != 32 || ranges[1].GetAddressPrefix() != "10.1.0.0" {
+		t.Fatalf("invalid source prefix ranges: %v", ranges)
+	}
+	if _, err := ParseCIDR("10.0.0"); err == nil {
+		t.Fatalf("expect error for invalid ip")
+	}
+}
+
+func TestRemoveExpiredRules(t *testing.T) {
+	now := time.Now()
+	v := &Virtual{
+		Uid: "deployments.authors",
+		Rules: []*Rule{
+			{LocalTunIPv4: "223.254.0.100", ExpireAt: &metav1.Time{Time: now.Add(-time.Second)}},
+			{LocalTunIPv4: "223.254.0.101", ExpireAt: &metav1.Time{Time: now.Add(time.Minute)}},
+			{LocalTunIPv4: "223.254.0.102"},
+		},
+	}
+	(&Processor{logger: logrus.StandardLogger()}).removeExpiredRules(v)
+	if len(v.Rules) != 2 || v.Rules[0].LocalTunIPv4 != "223.254.0.101" {
+		t.Fatalf("invalid rules after remove expired: %v", v.Rules)
+	}
+}
+
+// renew lease should not generate new snapshot
+func TestRenewLeaseNotChangeSnapshot(t *testing.T) {
+	snapshotCache := cache.NewSnapshotCache(false, cache.IDHash{}, logrus.StandardLogger())
+	p := NewProcessor(snapshotCache, logrus.StandardLogger(), nil)
+	load := func(expireAt time.Time) []*Virtual {
+		configList := []*Virtual{{
+			Uid:   "deployments.apps.productpage",
+			Ports: []corev1.ContainerPort{{ContainerPort: 9080, Protocol: corev1.ProtocolTCP}},
+			Rules: []*Rule{{Headers: map[string]string{"a": "1"}, LocalTunIPv4: "223.254.0.100"}},
+		}}
+		ApplyRuleLeases(configList, map[string]string{RuleLeaseKey("223.254.0.100"): expireAt.Format(time.RFC3339)})
+		return configList
+	}
+	p.Process(load(time.Now().Add(time.Minute)))
+	snapshot, err := snapshotCache.GetSnapshot("deployments.apps.productpage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Process(load(time.Now().Add(time.Minute * 2)))
+	renewed, _ := snapshotCache.GetSnapshot("deployments.apps.productpage")
+	if renewed.GetVersion(resource.ListenerType) != snapshot.GetVersion(resource.ListenerType) {
+		t.Fatalf("expect snapshot not changed after lease renewed")
+	}
+	p.Process(load(time.Now().Add(-time.Second)))
+	expired, _ := snapshotCache.GetSnapshot("deployments.apps.productpage")
+	if expired.GetVersion(resource.ListenerType) == snapshot.GetVersion(resource.ListenerType) {
+		t.Fatalf("expect snapshot changed after lease expired")
+	}
+}
+
+func TestFallback(t *testing.T) {
+	c := ToCluster("223.254.0.100_9080", true)
+	if len(c.HealthChecks) != 1 || c.OutlierDetection == nil {
+		t.Fatalf("expect health check and outlier detection for fallback cluster")
+	}
+	e := ToEndPoint("223.254.0.100_9080", "223.254.0.100", 9080, true)
+	if len(e.Endpoints) != 2 || e.Endpoints[1].Priority != 1 ||
+		e.Endpoints[1].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress() != "127.0.0.1" {
+		t.Fatalf("invalid fallback endpoints: %v", e.Endpoints)
+	}
+	e = ToEndPoint("efff::100_9080", "efff::100", 9080, true)
+	if e.Endpoints[1].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress() != "::1" {
+		t.Fatalf("invalid ipv6 fallback endpoints: %v", e.Endpoints)
+	}
+	if c = ToCluster("223.254.0.100_9080", false); len(c.HealthChecks) != 0 {
+		t.Fatalf("expect no health check without fallback")
+	}
+}
@@ -3,46 +3,133 @@ package controlplane
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"github.com/fsnotify/fsnotify"
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
 )
 
-func Main(filename string, port uint, logger *log.Logger) {
+// Main if filename is not empty, watch config file which mounted from configmap (traffic manager created by old
+// version), otherwise watch configmap directly, volume propagation of configmap may take a minute.
+// proxy rules in crd TrafficRule are merged into config if crd is installed
+func Main(filename string, port uint, logger *log.Logger, clientset kubernetes.Interface, client dynamic.Interface, namespace string) {
+	ctx := context.Background()
+	notifyCh := make(chan NotifyMessage, 100)
+	notify := func() {
+		select {
+		case notifyCh <- NotifyMessage{Operation: Modify, FilePath: filename}:
+		default:
+		}
+	}
+
+	podLister := watchPods(ctx, clientset, namespace, notify)
+
 	snapshotCache := cache.NewSnapshotCache(false, cache.IDHash{}, logger)
-	proc := NewProcessor(snapshotCache, logger)
+	proc := NewProcessor(snapshotCache, logger, podLister)
 
 	go func() {
-		ctx := context.Background()
 		server := serverv3.NewServer(ctx, snapshotCache, nil)
 		RunServer(ctx, server, port)
 	}()
 
-	notifyCh := make(chan NotifyMessage, 100)
+	var load func() ([]*Virtual, error)
+	if len(filename) != 0 {
+		load = func() ([]*Virtual, error) {
+			return ParseYaml(filename)
+		}
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			log.Fatal(fmt.Errorf("failed to create file watcher, err: %v", err))
+		}
+		defer watcher.Close()
+		if err = watcher.Add(filename); err != nil {
+			log.Fatal(fmt.Errorf("failed to add file: %s to wather, err: %v", filename, err))
+		}
+		go func() {
+			log.Fatal(Watch(watcher, filename, notifyCh))
+		}()
+	} else {
+		var err error
+		load, err = WatchConfigMap(ctx, clientset, namespace, notify)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// rules in crd TrafficRule and configmap are merged
+	if loadTrafficRules := WatchTrafficRules(ctx, clientset, client, namespace, notify); loadTrafficRules != nil {
+		loadConfig := load
+		load = func() ([]*Virtual, error) {
+			configList, err := loadConfig()
+			if err != nil {
+				return nil, err
+			}
+			rules, err := loadTrafficRules()
+			if err != nil {
+				return nil, err
+			}
+			return MergeTrafficRules(configList, rules), nil
+		}
+	}
 
 	notifyCh <- NotifyMessage{
 		Operation: Create,
 		FilePath:  filename,
 	}
 
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		log.Fatal(fmt.Errorf("failed to create file watcher, err: %v", err))
-	}
-	defer watcher.Close()
-	if err = watcher.Add(filename); err != nil {
-		log.Fatal(fmt.Errorf("failed to add file: %s to wather, err: %v", filename, err))
-	}
+	// rule expire without config change, so needs to check it periodically
 	go func() {
-		log.Fatal(Watch(watcher, filename, notifyCh))
+		ticker := time.NewTicker(time.Second * 10)
+		defer ticker.Stop()
+		for range ticker.C {
+			notify()
+		}
 	}()
 
 	for {
 		select {
-		case msg := <-notifyCh:
-			proc.ProcessFile(msg)
+		case <-notifyCh:
+			configList, err := load()
+			if err != nil {
+				logger.Errorf("error loading envoy config: %+v", err)
+				continue
+			}
+			proc.Process(configList)
 		}
 	}
 }
+
+// watchPods list and watch pods in namespace, it's used to resolve rule source labels to pod ip,
+// if traffic manager has no permission to list pods (created by old version), source labels will be ignored
+func watchPods(ctx context.Context, clientset kubernetes.Interface, namespace string, onChange func()) v1.PodLister {
+	if clientset == nil {
+		return nil
+	}
+	_, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		log.Warnf("can not list pods in namespace %s, rule source labels will be ignored, err: %v", namespace, err)
+		return nil
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, time.Minute*5, informers.WithNamespace(namespace))
+	informer := factory.Core().V1().Pods()
+	_, err = informer.Informer().AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { onChange() },
+		UpdateFunc: func(oldObj, newObj interface{}) { onChange() },
+		DeleteFunc: func(obj interface{}) { onChange() },
+	})
+	if err != nil {
+		log.Warnf("can not watch pods in namespace %s, rule source labels will be ignored, err: %v", namespace, err)
+		return nil
+	}
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	return informer.Lister()
+}
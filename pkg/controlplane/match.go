@@ -0,0 +1,180 @@
+package controlplane
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+)
+
+// Rule headers key and value support below format:
+//
+//	key=value    header key exactly equal to value (ignore case)
+//	key=~regex   header key contains match of regex, anchor it by ^ or $, eg: x-user=~^qa-
+//	key=prefix*  header key starts with prefix (ignore case)
+//	key=*        header key is present
+//	:path=...    request path, value format same as header, eg: :path=/api/v2/*
+//	:method=GET  request method
+//	:grpc=...    grpc request, value pkg.Svc/Method, pkg.Svc/* (all method of service), * (all grpc) or ~regex (whole method)
+//	?name=...    query parameter name, value format same as header, eg: ?version=v2
+//
+// grpc metadata is http2 header, so metadata key can be matched as header, binary metadata (key ends with -bin)
+// value is base64 encoded
+const (
+	PseudoHeaderPath    = ":path"
+	PseudoHeaderMethod  = ":method"
+	PseudoHeaderGrpc    = ":grpc"
+	QueryParameterFlag  = "?"
+	MatchRegexFlag      = "~"
+	MatchPrefixFlag     = "*"
+	MatchPresentPattern = "*"
+)
+
+type matchType int
+
+const (
+	matchExact matchType = iota
+	matchPrefix
+	matchRegex
+	matchPresent
+)
+
+func parseMatchValue(value string) (matchType, string) {
+	switch {
+	case value == MatchPresentPattern:
+		return matchPresent, ""
+	case strings.HasPrefix(value, MatchRegexFlag):
+		return matchRegex, strings.TrimPrefix(value, MatchRegexFlag)
+	case strings.HasSuffix(value, MatchPrefixFlag):
+		return matchPrefix, strings.TrimSuffix(value, MatchPrefixFlag)
+	default:
+		return matchExact, value
+	}
+}
+
+// ValidateHeaders check rule headers format, especially regex, envoy use RE2 same as golang
+func ValidateHeaders(headers map[string]string) error {
+	for k, v := range headers {
+		if len(strings.TrimPrefix(k, QueryParameterFlag)) == 0 {
+			return fmt.Errorf("invalid rule %s=%s, key can not be empty", k, v)
+		}
+		t, value := parseMatchValue(v)
+		switch t {
+		case matchRegex:
+			if _, err := regexp.Compile(partialRegex(value)); err != nil {
+				return fmt.Errorf("invalid rule %s=%s, regex error: %v", k, v, err)
+			}
+		case matchExact:
+			if k == PseudoHeaderGrpc && len(strings.Split(strings.Trim(value, "/"), "/")) != 2 {
+				return fmt.Errorf("invalid rule %s=%s, grpc method format is package.Service/Method", k, v)
+			}
+		case matchPrefix, matchPresent:
+			if k == PseudoHeaderMethod {
+				return fmt.Errorf("invalid rule %s=%s, method only support exact or regex match", k, v)
+			}
+		}
+	}
+	return nil
+}
+
+// partialRegex envoy regex is full match, wrap regex by .* unless it is anchored, so ~^qa- matches qa-1
+func partialRegex(regex string) string {
+	prefix, suffix := ".*", ".*"
+	if strings.HasPrefix(regex, "^") || strings.HasPrefix(regex, ".*") {
+		prefix = ""
+	}
+	if !strings.HasSuffix(regex, `\$`) && !strings.HasSuffix(regex, `\.*`) &&
+		(strings.HasSuffix(regex, "$") || strings.HasSuffix(regex, ".*")) {
+		suffix = ""
+	}
+	if prefix == "" && suffix == "" {
+		return regex
+	}
+	return prefix + "(?:" + regex + ")" + suffix
+}
+
+func toRegexMatcher(regex string) *matcher.RegexMatcher {
+	return &matcher.RegexMatcher{
+		EngineType: &matcher.RegexMatcher_GoogleRe2{GoogleRe2: &matcher.RegexMatcher_GoogleRE2{}},
+		Regex:      regex,
+	}
+}
+
+func toStringMatcher(t matchType, value string) *matcher.StringMatcher {
+	switch t {
+	case matchRegex:
+		return &matcher.StringMatcher{
+			MatchPattern: &matcher.StringMatcher_SafeRegex{
+				SafeRegex: toRegexMatcher(partialRegex(value)),
+			},
+		}
+	case matchPrefix:
+		return &matcher.StringMatcher{
+			MatchPattern: &matcher.StringMatcher_Prefix{Prefix: value},
+			IgnoreCase:   true,
+		}
+	default:
+		return &matcher.StringMatcher{
+			MatchPattern: &matcher.StringMatcher_Exact{Exact: value},
+			IgnoreCase:   true,
+		}
+	}
+}
+
+// ToRouteMatch convert rule headers to envoy route match, default match all path
+func ToRouteMatch(headers map[string]string) *route.RouteMatch {
+	var match = &route.RouteMatch{
+		PathSpecifier: &route.RouteMatch_Prefix{
+			Prefix: "/",
+		},
+	}
+	for k, v := range headers {
+		t, value := parseMatchValue(v)
+		switch {
+		case k == PseudoHeaderPath:
+			switch t {
+			case matchExact:
+				match.PathSpecifier = &route.RouteMatch_Path{Path: value}
+			case matchPrefix:
+				match.PathSpecifier = &route.RouteMatch_Prefix{Prefix: value}
+			case matchRegex:
+				match.PathSpecifier = &route.RouteMatch_SafeRegex{SafeRegex: toRegexMatcher(partialRegex(value))}
+			}
+		case k == PseudoHeaderGrpc:
+			// grpc request path is /package.Service/Method
+			match.Grpc = &route.RouteMatch_GrpcRouteMatchOptions{}
+			switch t {
+			case matchExact:
+				match.PathSpecifier = &route.RouteMatch_Path{Path: "/" + strings.Trim(value, "/")}
+			case matchPrefix:
+				match.PathSpecifier = &route.RouteMatch_Prefix{Prefix: "/" + strings.Trim(value, "/") + "/"}
+			case matchRegex:
+				match.PathSpecifier = &route.RouteMatch_SafeRegex{SafeRegex: toRegexMatcher("/" + strings.TrimPrefix(value, "/"))}
+			}
+		case strings.HasPrefix(k, QueryParameterFlag):
+			q := &route.QueryParameterMatcher{Name: strings.TrimPrefix(k, QueryParameterFlag)}
+			if t == matchPresent {
+				q.QueryParameterMatchSpecifier = &route.QueryParameterMatcher_PresentMatch{PresentMatch: true}
+			} else {
+				q.QueryParameterMatchSpecifier = &route.QueryParameterMatcher_StringMatch{StringMatch: toStringMatcher(t, value)}
+			}
+			match.QueryParameters = append(match.QueryParameters, q)
+		default:
+			h := &route.HeaderMatcher{Name: k}
+			if t == matchPresent {
+				h.HeaderMatchSpecifier = &route.HeaderMatcher_PresentMatch{PresentMatch: true}
+			} else {
+				sm := toStringMatcher(t, value)
+				// http method is case-sensitive
+				if k == PseudoHeaderMethod {
+					sm.IgnoreCase = false
+				}
+				h.HeaderMatchSpecifier = &route.HeaderMatcher_StringMatch{StringMatch: sm}
+			}
+			match.Headers = append(match.Headers, h)
+		}
+	}
+	return match
+}
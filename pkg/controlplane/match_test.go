@@ -0,0 +1,105 @@
+package controlplane
+
+import (
+	"regexp"
+	"testing"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+)
+
+func TestToRouteMatch(t *testing.T) {
+	match := ToRouteMatch(map[string]string{
+		"x-user":  "~^qa-",
+		"x-env":   "dev*",
+		"x-debug": "*",
+		"a":       "1",
+		":path":   "/api/v2/*",
+		":method": "GET",
+		"?ver":    "v2",
+	})
+	if prefix := match.GetPrefix(); prefix != "/api/v2/" {
+		t.Fatalf("expect path prefix /api/v2/, but got %s", prefix)
+	}
+	if len(match.QueryParameters) != 1 || match.QueryParameters[0].GetName() != "ver" ||
+		match.QueryParameters[0].GetStringMatch().GetExact() != "v2" {
+		t.Fatalf("invalid query parameter match: %v", match.QueryParameters)
+	}
+	headers := map[string]*route.HeaderMatcher{}
+	for _, h := range match.Headers {
+		headers[h.Name] = h
+	}
+	if len(headers) != 5 {
+		t.Fatalf("expect 5 header matchers, but got %d", len(headers))
+	}
+	if headers["x-user"].GetStringMatch().GetSafeRegex().GetRegex() != "(?:^qa-).*" {
+		t.Fatalf("invalid regex match: %v", headers["x-user"])
+	}
+	if headers["x-env"].GetStringMatch().GetPrefix() != "dev" {
+		t.Fatalf("invalid prefix match: %v", headers["x-env"])
+	}
+	if !headers["x-debug"].GetPresentMatch() {
+		t.Fatalf("invalid present match: %v", headers["x-debug"])
+	}
+	if headers["a"].GetStringMatch().GetExact() != "1" {
+		t.Fatalf("invalid exact match: %v", headers["a"])
+	}
+	if m := headers[":method"].GetStringMatch(); m.GetExact() != "GET" || m.GetIgnoreCase() {
+		t.Fatalf("invalid method match: %v", headers[":method"])
+	}
+}
+
+func TestValidateHeaders(t *testing.T) {
+	if err := ValidateHeaders(map[string]string{"x-user": "~qa-.*", ":path": "/api/*"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, headers := range []map[string]string{
+		{"x-user": "~qa-("},
+		{":method": "G*"},
+		{"?": "1"},
+	} {
+		if err := ValidateHeaders(headers); err == nil {
+			t.Fatalf("expect error for %v", headers)
+		}
+	}
+}
+
+func TestPartialRegex(t *testing.T) {
+	for regex, cases := range map[string]map[string]bool{
+		"^qa-":       {"qa-1": true, "x-qa-1": false},
+		"qa-":        {"qa-1": true, "x-qa-1": true, "dev": false},
+		"-1$":        {"qa-1": true, "qa-10": false},
+		"^qa-1$":     {"qa-1": true, "qa-10": false},
+		"a|b":        {"xax": true, "xbx": true, "xcx": false},
+		"qa-.*":      {"x-qa-1": true},
+		`1\$`:        {"1$": true, "x1$x": true},
+		"[0-9]{1,3}": {"v12": true, "v": false},
+	} {
+		// envoy regex is full match
+		re := regexp.MustCompile("^(?:" + partialRegex(regex) + ")$")
+		for value, expect := range cases {
+			if re.MatchString(value) != expect {
+				t.Fatalf("expect %s match %s is %v, but got %v", regex, value, expect, !expect)
+			}
+		}
+	}
+}
+
+func TestToRouteMatchGrpc(t *testing.T) {
+	for value, path := range map[string]string{
+		"pkg.Svc/Method": "/pkg.Svc/Method",
+		"pkg.Svc/*":      "/pkg.Svc/",
+		"~pkg.Svc/.*":    "/pkg.Svc/.*",
+	} {
+		match := ToRouteMatch(map[string]string{":grpc": value})
+		if match.GetGrpc() == nil {
+			t.Fatalf("expect grpc match for %s", value)
+		}
+		got := match.GetPath() + match.GetPrefix() + match.GetSafeRegex().GetRegex()
+		if got != path {
+			t.Fatalf("expect path %s, but got %s", path, got)
+		}
+	}
+	if err := ValidateHeaders(map[string]string{":grpc": "pkg.Svc"}); err == nil {
+		t.Fatalf("expect error for grpc method without service")
+	}
+}
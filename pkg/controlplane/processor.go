@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
@@ -15,8 +16,10 @@ import (
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
 	utilcache "k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	v1 "k8s.io/client-go/listers/core/v1"
 )
 
 type Processor struct {
@@ -25,14 +28,17 @@ type Processor struct {
 	version int64
 
 	expireCache *utilcache.Expiring
+	// podLister is used to resolve rule source labels, it's nil if can not list pods
+	podLister v1.PodLister
 }
 
-func NewProcessor(cache cache.SnapshotCache, log *logrus.Logger) *Processor {
+func NewProcessor(cache cache.SnapshotCache, log *logrus.Logger, podLister v1.PodLister) *Processor {
 	return &Processor{
 		cache:       cache,
 		logger:      log,
 		version:     rand.Int63n(1000),
 		expireCache: utilcache.NewExpiring(),
+		podLister:   podLister,
 	}
 }
 
@@ -44,18 +50,16 @@ func (p *Processor) newVersion() string {
 	return strconv.FormatInt(p.version, 10)
 }
 
-func (p *Processor) ProcessFile(file NotifyMessage) {
-	configList, err := ParseYaml(file.FilePath)
-	if err != nil {
-		p.logger.Errorf("error parsing yaml file: %+v", err)
-		return
-	}
+func (p *Processor) Process(configList []*Virtual) {
+	var err error
 	for _, config := range configList {
 		if len(config.Uid) == 0 {
 			continue
 		}
+		p.removeExpiredRules(config)
+		p.resolveSourceLabels(config)
 		lastConfig, ok := p.expireCache.Get(config.Uid)
-		if ok && reflect.DeepEqual(lastConfig.(*Virtual), config) {
+		if ok && reflect.DeepEqual(withoutLease(lastConfig.(*Virtual)), withoutLease(config)) {
 			marshal, _ := json.Marshal(config)
 			p.logger.Debugf("config are same, not needs to update, config: %s", string(marshal))
 			continue
@@ -93,18 +97,72 @@ func (p *Processor) ProcessFile(file NotifyMessage) {
 	}
 }
 
-func ParseYaml(file string) ([]*Virtual, error) {
-	var virtualList = make([]*Virtual, 0)
+// removeExpiredRules remove rules which client not renew lease
+func (p *Processor) removeExpiredRules(config *Virtual) {
+	now := time.Now()
+	var rules []*Rule
+	for _, rule := range config.Rules {
+		if rule.IsExpired(now) {
+			p.logger.Debugf("rule of %s with local tun ip %s is expired at %v", config.Uid, rule.LocalTunIPv4, rule.ExpireAt)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	config.Rules = rules
+}
+
+// withoutLease copy of config without lease of rules, lease is renewed periodically, renewing it must not generate
+// new snapshot, otherwise envoy reloads config every time
+func withoutLease(config *Virtual) *Virtual {
+	c := *config
+	c.Rules = nil
+	for _, rule := range config.Rules {
+		r := *rule
+		r.ExpireAt = nil
+		c.Rules = append(c.Rules, &r)
+	}
+	return &c
+}
+
+// resolveSourceLabels envoy only knows ip, so append ip of pods which match rule source labels to rule source ips
+func (p *Processor) resolveSourceLabels(config *Virtual) {
+	for _, rule := range config.Rules {
+		if len(rule.SourceLabels) == 0 || p.podLister == nil {
+			continue
+		}
+		pods, err := p.podLister.List(labels.SelectorFromSet(rule.SourceLabels))
+		if err != nil {
+			p.logger.Errorf("list pods with labels %v error: %v", rule.SourceLabels, err)
+			continue
+		}
+		var ips []string
+		for _, pod := range pods {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			for _, podIP := range pod.Status.PodIPs {
+				ips = append(ips, podIP.IP)
+			}
+		}
+		// keep it stable, otherwise config will be treated as changed
+		sort.Strings(ips)
+		rule.SourceIPs = append(rule.SourceIPs, ips...)
+	}
+}
 
+func ParseYaml(file string) ([]*Virtual, error) {
 	yamlFile, err := os.ReadFile(file)
 	if err != nil {
 		return nil, fmt.Errorf("Error reading YAML file: %s\n", err)
 	}
+	return ParseYamlContent(yamlFile)
+}
 
-	err = yaml.Unmarshal(yamlFile, &virtualList)
+func ParseYamlContent(content []byte) ([]*Virtual, error) {
+	var virtualList = make([]*Virtual, 0)
+	err := yaml.Unmarshal(content, &virtualList)
 	if err != nil {
 		return nil, err
 	}
-
 	return virtualList, nil
 }
@@ -0,0 +1,200 @@
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	TrafficRuleGroup   = "kubevpn.io"
+	TrafficRuleVersion = "v1alpha1"
+	TrafficRuleKind    = "TrafficRule"
+	TrafficRulePlural  = "trafficrules"
+)
+
+var TrafficRuleGroupVersionResource = schema.GroupVersionResource{
+	Group:    TrafficRuleGroup,
+	Version:  TrafficRuleVersion,
+	Resource: TrafficRulePlural,
+}
+
+// TrafficRule one proxy rule of one client on one workload, every client owns its rules, so concurrent proxy
+// will not conflict on configmap, and it can be inspected by kubectl get trafficrules
+type TrafficRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TrafficRuleSpec `json:"spec"`
+}
+
+type TrafficRuleSpec struct {
+	// Workload same as Virtual.Uid, group.resource.name
+	Workload string                 `json:"workload"`
+	Ports    []corev1.ContainerPort `json:"ports,omitempty"`
+	Owner    string                 `json:"owner,omitempty"`
+	Rule     *Rule                  `json:"rule"`
+}
+
+// TrafficRuleName name of rule which route workload traffic to local tun ip
+func TrafficRuleName(nodeID, localTunIPv4 string) string {
+	return fmt.Sprintf("%s.%s", nodeID, localTunIPv4)
+}
+
+func NewTrafficRule(namespace, nodeID, owner string, rule *Rule, ports []corev1.ContainerPort) *TrafficRule {
+	return &TrafficRule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: TrafficRuleGroupVersionResource.GroupVersion().String(),
+			Kind:       TrafficRuleKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TrafficRuleName(nodeID, rule.LocalTunIPv4),
+			Namespace: namespace,
+		},
+		Spec: TrafficRuleSpec{
+			Workload: nodeID,
+			Ports:    ports,
+			Owner:    owner,
+			Rule:     rule,
+		},
+	}
+}
+
+func (t *TrafficRule) ToUnstructured() (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(t)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+func FromUnstructured(u *unstructured.Unstructured) (*TrafficRule, error) {
+	var t TrafficRule
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &t); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s, err: %v", TrafficRuleKind, u.GetName(), err)
+	}
+	return &t, nil
+}
+
+// ListTrafficRules returns nil if crd is not installed
+func ListTrafficRules(ctx context.Context, client dynamic.Interface, namespace string) ([]*TrafficRule, error) {
+	list, err := client.Resource(TrafficRuleGroupVersionResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result []*TrafficRule
+	for i := range list.Items {
+		t, err := FromUnstructured(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// MergeTrafficRules merge traffic rules into virtual list which comes from configmap
+func MergeTrafficRules(virtualList []*Virtual, rules []*TrafficRule) []*Virtual {
+	// keep it stable, otherwise config will be treated as changed
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	for _, t := range rules {
+		if t.Spec.Rule == nil || len(t.Spec.Workload) == 0 {
+			continue
+		}
+		var virtual *Virtual
+		for _, v := range virtualList {
+			if v.Uid == t.Spec.Workload {
+				virtual = v
+				break
+			}
+		}
+		if virtual == nil {
+			virtual = &Virtual{Uid: t.Spec.Workload}
+			virtualList = append(virtualList, virtual)
+		}
+		if virtual.Ports == nil {
+			virtual.Ports = t.Spec.Ports
+		}
+		virtual.Rules = append(virtual.Rules, t.Spec.Rule)
+	}
+	return virtualList
+}
+
+// TrafficRuleCRD namespaced crd, rule is preserved as it is, control plane validate it
+func TrafficRuleCRD() *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s.%s", TrafficRulePlural, TrafficRuleGroup),
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: TrafficRuleGroup,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     TrafficRulePlural,
+				Singular:   "trafficrule",
+				Kind:       TrafficRuleKind,
+				ListKind:   TrafficRuleKind + "List",
+				ShortNames: []string{"tr"},
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    TrafficRuleVersion,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type:     "object",
+								Required: []string{"workload", "rule"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"workload": {Type: "string"},
+									"owner":    {Type: "string"},
+									"ports": {
+										Type: "array",
+										Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+											Schema: &apiextensionsv1.JSONSchemaProps{
+												Type:                   "object",
+												XPreserveUnknownFields: pointer.Bool(true),
+											},
+										},
+									},
+									"rule": {
+										Type:                   "object",
+										XPreserveUnknownFields: pointer.Bool(true),
+									},
+								},
+							},
+						},
+					},
+				},
+				AdditionalPrinterColumns: []apiextensionsv1.CustomResourceColumnDefinition{
+					{Name: "Workload", Type: "string", JSONPath: ".spec.workload"},
+					{Name: "Target", Type: "string", JSONPath: ".spec.rule.LocalTunIPv4"},
+					{Name: "Owner", Type: "string", JSONPath: ".spec.owner"},
+					{Name: "Expire", Type: "string", JSONPath: ".spec.rule.ExpireAt"},
+				},
+			}},
+		},
+	}
+}
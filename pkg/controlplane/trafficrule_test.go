@@ -0,0 +1,39 @@
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTrafficRuleConvert(t *testing.T) {
+	rule := &Rule{
+		Headers:      map[string]string{"a": "1"},
+		LocalTunIPv4: "223.254.0.100",
+		LocalTunIPv6: "efff:ffff::100",
+		Weight:       10,
+		ExpireAt:     &metav1.Time{Time: time.Now().Add(time.Minute).Truncate(time.Second)},
+	}
+	ports := []corev1.ContainerPort{{ContainerPort: 9080, Protocol: corev1.ProtocolTCP}}
+	u, err := NewTrafficRule("default", "deployments.apps.productpage", "naison", rule, ports).ToUnstructured()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.GetName() != "deployments.apps.productpage.223.254.0.100" || u.GetKind() != TrafficRuleKind {
+		t.Fatalf("invalid traffic rule: %v", u.Object)
+	}
+	tr, err := FromUnstructured(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Spec.Rule.Headers["a"] != "1" || tr.Spec.Rule.Weight != 10 || !tr.Spec.Rule.ExpireAt.Equal(rule.ExpireAt) {
+		t.Fatalf("invalid traffic rule after convert: %v", tr.Spec.Rule)
+	}
+
+	virtualList := MergeTrafficRules([]*Virtual{{Uid: "deployments.apps.reviews", Rules: []*Rule{{LocalTunIPv4: "223.254.0.101"}}}}, []*TrafficRule{tr})
+	if len(virtualList) != 2 || virtualList[1].Uid != "deployments.apps.productpage" || len(virtualList[1].Ports) != 1 {
+		t.Fatalf("invalid virtual list after merge: %v", virtualList)
+	}
+}
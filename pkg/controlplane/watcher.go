@@ -1,10 +1,25 @@
 package controlplane
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
 )
 
 type OperationType int
@@ -60,3 +75,104 @@ func Watch(watcher *fsnotify.Watcher, filename string, notifyCh chan<- NotifyMes
 		}
 	}
 }
+
+// WatchConfigMap watch envoy config in configmap kubevpn-traffic-manager through informer, call onChange once
+// configmap changed, returns func to load envoy config from informer cache
+func WatchConfigMap(ctx context.Context, clientset kubernetes.Interface, namespace string, onChange func()) (func() ([]*Virtual, error), error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("can not watch configmap %s without kubernetes clientset", config.ConfigMapPodTrafficManager)
+	}
+	// role only has permission of configmap kubevpn-traffic-manager, so list and watch with field selector
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, time.Minute*5,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", config.ConfigMapPodTrafficManager).String()
+		}),
+	)
+	informer := factory.Core().V1().ConfigMaps()
+	_, err := informer.Informer().AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { onChange() },
+		UpdateFunc: func(oldObj, newObj interface{}) { onChange() },
+		DeleteFunc: func(obj interface{}) { onChange() },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch configmap %s, err: %v", config.ConfigMapPodTrafficManager, err)
+	}
+	factory.Start(ctx.Done())
+	for _, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, fmt.Errorf("failed to sync configmap %s", config.ConfigMapPodTrafficManager)
+		}
+	}
+	lister := informer.Lister()
+	return func() ([]*Virtual, error) {
+		configMap, err := lister.ConfigMaps(namespace).Get(config.ConfigMapPodTrafficManager)
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		configList, err := ParseYamlContent([]byte(configMap.Data[config.KeyEnvoy]))
+		if err != nil {
+			return nil, err
+		}
+		ApplyRuleLeases(configList, configMap.Data)
+		return configList, nil
+	}, nil
+}
+
+// WatchTrafficRules watch crd TrafficRule through informer, call onChange once rules changed, returns func to load
+// rules from informer cache, if crd is not installed or no permission, returns nil and load rules from configmap only.
+// once watch successfully, mark it in configmap, client will save proxy rules as TrafficRule
+func WatchTrafficRules(ctx context.Context, clientset kubernetes.Interface, client dynamic.Interface, namespace string, onChange func()) func() ([]*TrafficRule, error) {
+	if clientset == nil || client == nil {
+		return nil
+	}
+	_, err := client.Resource(TrafficRuleGroupVersionResource).Namespace(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		log.Warnf("can not list %s in namespace %s, only load proxy rules from configmap, err: %v", TrafficRulePlural, namespace, err)
+		return nil
+	}
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, time.Minute*5, namespace, nil)
+	informer := factory.ForResource(TrafficRuleGroupVersionResource)
+	_, err = informer.Informer().AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { onChange() },
+		UpdateFunc: func(oldObj, newObj interface{}) { onChange() },
+		DeleteFunc: func(obj interface{}) { onChange() },
+	})
+	if err != nil {
+		log.Warnf("can not watch %s in namespace %s, only load proxy rules from configmap, err: %v", TrafficRulePlural, namespace, err)
+		return nil
+	}
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	patch := []byte(fmt.Sprintf(`{"data":{"%s":"true"}}`, config.KeyTrafficRule))
+	_, err = clientset.CoreV1().ConfigMaps(namespace).Patch(ctx, config.ConfigMapPodTrafficManager, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		log.Warnf("failed to mark %s enabled in configmap %s, err: %v", TrafficRulePlural, config.ConfigMapPodTrafficManager, err)
+	}
+
+	lister := informer.Lister()
+	return func() ([]*TrafficRule, error) {
+		list, err := lister.ByNamespace(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var result []*TrafficRule
+		for _, obj := range list {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			t, err := FromUnstructured(u)
+			if err != nil {
+				log.Warn(err)
+				continue
+			}
+			result = append(result, t)
+		}
+		return result, nil
+	}
+}
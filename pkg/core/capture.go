@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// captureBufferSize packets are dropped if subscriber is slower than tunnel
+const captureBufferSize = 1024
+
+// Captures tap of tunnel packets, client side tap packets between tun device and traffic manager,
+// traffic manager tap packets routed between peers and tun device
+var Captures = &captureRegistry{subscribers: map[*Capture]struct{}{}}
+
+type CapturedPacket struct {
+	Timestamp time.Time
+	Data      []byte
+}
+
+// Capture one subscriber of tunnel packets
+type Capture struct {
+	filter  *CaptureFilter
+	C       chan *CapturedPacket
+	dropped atomic.Uint64
+}
+
+type captureRegistry struct {
+	lock        sync.RWMutex
+	count       atomic.Int32
+	subscribers map[*Capture]struct{}
+}
+
+// Subscribe start capture packets which match filter, empty filter match all packets
+func (r *captureRegistry) Subscribe(filter string) (*Capture, error) {
+	f, err := ParseCaptureFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	c := &Capture{filter: f, C: make(chan *CapturedPacket, captureBufferSize)}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.subscribers[c] = struct{}{}
+	r.count.Store(int32(len(r.subscribers)))
+	return c, nil
+}
+
+func (r *captureRegistry) Unsubscribe(c *Capture) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.subscribers, c)
+	r.count.Store(int32(len(r.subscribers)))
+	if dropped := c.dropped.Load(); dropped != 0 {
+		log.Warnf("[capture] %d packets dropped by slow subscriber", dropped)
+	}
+}
+
+// Tap copy packet to subscribers, it does nothing if no one is capturing
+func (r *captureRegistry) Tap(packet []byte) {
+	if r.count.Load() == 0 {
+		return
+	}
+	var info = parsePacketInfo(packet)
+	var captured *CapturedPacket
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for c := range r.subscribers {
+		if !c.filter.match(info) {
+			continue
+		}
+		if captured == nil {
+			captured = &CapturedPacket{Timestamp: time.Now(), Data: append([]byte(nil), packet...)}
+		}
+		select {
+		case c.C <- captured:
+		default:
+			c.dropped.Add(1)
+		}
+	}
+}
+
+// WriteCapture write packets which match filter to w as pcapng until ctx is done
+func WriteCapture(ctx context.Context, w io.Writer, filter string) error {
+	c, err := Captures.Subscribe(filter)
+	if err != nil {
+		return err
+	}
+	defer Captures.Unsubscribe(c)
+	writer, err := pcapgo.NewNgWriter(w, layers.LinkTypeRaw)
+	if err != nil {
+		return err
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case p := <-c.C:
+			ci := gopacket.CaptureInfo{Timestamp: p.Timestamp, CaptureLength: len(p.Data), Length: len(p.Data)}
+			if err = writer.WritePacket(ci, p.Data); err != nil {
+				return err
+			}
+			if err = writer.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// CaptureHandler stream packets as pcapng, filter is query param, eg: /capture?filter=tcp+port+80
+func CaptureHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("filter")
+		if _, err := ParseCaptureFilter(filter); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		err := WriteCapture(r.Context(), &flushWriter{w: w}, filter)
+		if err != nil {
+			log.Debugf("[capture] %s stop capture: %v", r.RemoteAddr, err)
+		}
+	})
+}
+
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
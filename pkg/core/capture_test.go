@@ -0,0 +1,58 @@
+package core
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func genTCPPacket(t *testing.T, src, dst string, srcPort, dstPort int) []byte {
+	ip := &layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolTCP, SrcIP: net.ParseIP(src), DstIP: net.ParseIP(dst)}
+	tcp := &layers.TCP{SrcPort: layers.TCPPort(srcPort), DstPort: layers.TCPPort(dstPort), SYN: true}
+	_ = tcp.SetNetworkLayerForChecksum(ip)
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, ip, tcp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCaptureFilter(t *testing.T) {
+	packet := genTCPPacket(t, "223.254.0.100", "10.244.0.12", 53124, 80)
+	for expr, expect := range map[string]bool{
+		"":                                       true,
+		"tcp port 80":                            true,
+		"udp or icmp":                            false,
+		"src host 223.254.0.100 and dst port 80": true,
+		"dst host 223.254.0.100":                 false,
+		"net 10.244.0.0/16 && !(port 443)":       true,
+		"ip6 or (ip and src port 80)":            false,
+	} {
+		f, err := ParseCaptureFilter(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Match(packet) != expect {
+			t.Fatalf("filter %q expect %v", expr, expect)
+		}
+	}
+	for _, expr := range []string{"host", "port abc", "(tcp", "tcp)", "foo"} {
+		if _, err := ParseCaptureFilter(expr); err == nil {
+			t.Fatalf("expect error for filter %q", expr)
+		}
+	}
+
+	c, err := Captures.Subscribe("port 80")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Captures.Unsubscribe(c)
+	Captures.Tap(genTCPPacket(t, "223.254.0.100", "10.244.0.12", 53124, 443))
+	Captures.Tap(packet)
+	if p := <-c.C; string(p.Data) != string(packet) {
+		t.Fatalf("captured packet not match")
+	}
+}
@@ -0,0 +1,251 @@
+package core
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/gopacket/layers"
+
+	"github.com/wencaiwulue/kubevpn/pkg/util"
+)
+
+// CaptureFilter subset of pcap filter syntax, it is matched in go instead of compiled to BPF,
+// eg: "host 223.254.0.100 and tcp port 80", "not icmp", "udp dst port 53"
+// primitives: [src|dst] host <ip>, [src|dst] net <cidr>, [src|dst] port <port>, ip, ip6, tcp, udp, icmp, icmp6,
+// they can be combined with and(&&), or(||), not(!) and parentheses, adjacent primitives means and
+type CaptureFilter struct {
+	expr string
+	m    func(*packetInfo) bool
+}
+
+// ParseCaptureFilter empty expression match all packets
+func ParseCaptureFilter(expr string) (*CaptureFilter, error) {
+	f := &CaptureFilter{expr: expr}
+	r := strings.NewReplacer("(", " ( ", ")", " ) ", "&&", " and ", "||", " or ", "!", " not ")
+	p := &filterParser{tokens: strings.Fields(r.Replace(expr))}
+	if len(p.tokens) == 0 {
+		return f, nil
+	}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %v", expr, err)
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %q", expr, tok)
+	}
+	f.m = m
+	return f, nil
+}
+
+func (f *CaptureFilter) String() string {
+	return f.expr
+}
+
+// Match ip packet
+func (f *CaptureFilter) Match(packet []byte) bool {
+	return f.match(parsePacketInfo(packet))
+}
+
+func (f *CaptureFilter) match(info *packetInfo) bool {
+	if f == nil || f.m == nil {
+		return true
+	}
+	return info != nil && f.m(info)
+}
+
+type packetInfo struct {
+	ipv6     bool
+	src, dst net.IP
+	protocol layers.IPProtocol
+	// only tcp and udp packet has port
+	hasPort          bool
+	srcPort, dstPort uint16
+}
+
+// parsePacketInfo ipv6 extension headers are not parsed
+func parsePacketInfo(packet []byte) *packetInfo {
+	var info = &packetInfo{}
+	var payload []byte
+	if util.IsIPv4(packet) && len(packet) >= 20 {
+		ihl := int(packet[0]&0x0f) * 4
+		if ihl < 20 || len(packet) < ihl {
+			return nil
+		}
+		info.src, info.dst = packet[12:16], packet[16:20]
+		info.protocol = layers.IPProtocol(packet[9])
+		// only first fragment has transport header
+		if binary.BigEndian.Uint16(packet[6:8])&0x1fff == 0 {
+			payload = packet[ihl:]
+		}
+	} else if util.IsIPv6(packet) && len(packet) >= 40 {
+		info.ipv6 = true
+		info.src, info.dst = packet[8:24], packet[24:40]
+		info.protocol = layers.IPProtocol(packet[6])
+		payload = packet[40:]
+	} else {
+		return nil
+	}
+	if (info.protocol == layers.IPProtocolTCP || info.protocol == layers.IPProtocolUDP) && len(payload) >= 4 {
+		info.hasPort = true
+		info.srcPort = binary.BigEndian.Uint16(payload[0:2])
+		info.dstPort = binary.BigEndian.Uint16(payload[2:4])
+	}
+	return info
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return strings.ToLower(p.tokens[p.pos])
+	}
+	return ""
+}
+
+func (p *filterParser) next() string {
+	tok := p.peek()
+	if tok != "" {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) parseOr() (func(*packetInfo) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(info *packetInfo) bool { return l(info) || right(info) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (func(*packetInfo) bool, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != "" && tok != "or" && tok != ")"; tok = p.peek() {
+		if tok == "and" {
+			p.next()
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(info *packetInfo) bool { return l(info) && right(info) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (func(*packetInfo) bool, error) {
+	if p.peek() != "not" {
+		return p.parsePrimitive()
+	}
+	p.next()
+	m, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return func(info *packetInfo) bool { return !m(info) }, nil
+}
+
+func (p *filterParser) parsePrimitive() (func(*packetInfo) bool, error) {
+	tok := p.next()
+	switch tok {
+	case "(":
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		return m, nil
+	case "src", "dst":
+		return p.parseQualifier(tok, p.next())
+	case "host", "net", "port":
+		return p.parseQualifier("", tok)
+	case "ip":
+		return func(info *packetInfo) bool { return !info.ipv6 }, nil
+	case "ip6":
+		return func(info *packetInfo) bool { return info.ipv6 }, nil
+	case "tcp":
+		return func(info *packetInfo) bool { return info.protocol == layers.IPProtocolTCP }, nil
+	case "udp":
+		return func(info *packetInfo) bool { return info.protocol == layers.IPProtocolUDP }, nil
+	case "icmp":
+		return func(info *packetInfo) bool { return !info.ipv6 && info.protocol == layers.IPProtocolICMPv4 }, nil
+	case "icmp6":
+		return func(info *packetInfo) bool { return info.ipv6 && info.protocol == layers.IPProtocolICMPv6 }, nil
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unknown primitive %q", tok)
+	}
+}
+
+// parseQualifier dir is src, dst or empty which means src or dst
+func (p *filterParser) parseQualifier(dir, typ string) (func(*packetInfo) bool, error) {
+	value := p.next()
+	if value == "" {
+		return nil, fmt.Errorf("missing value of %s", typ)
+	}
+	var matchIP func(ip net.IP) bool
+	switch typ {
+	case "host":
+		host := net.ParseIP(value)
+		if host == nil {
+			return nil, fmt.Errorf("invalid host %q", value)
+		}
+		matchIP = host.Equal
+	case "net":
+		_, cidr, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid net %q", value)
+		}
+		matchIP = cidr.Contains
+	case "port":
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", value)
+		}
+		return func(info *packetInfo) bool {
+			if !info.hasPort {
+				return false
+			}
+			switch dir {
+			case "src":
+				return info.srcPort == uint16(port)
+			case "dst":
+				return info.dstPort == uint16(port)
+			}
+			return info.srcPort == uint16(port) || info.dstPort == uint16(port)
+		}, nil
+	default:
+		return nil, fmt.Errorf("expect host, net or port after %s, but got %q", dir, typ)
+	}
+	return func(info *packetInfo) bool {
+		switch dir {
+		case "src":
+			return matchIP(info.src)
+		case "dst":
+			return matchIP(info.dst)
+		}
+		return matchIP(info.src) || matchIP(info.dst)
+	}, nil
+}
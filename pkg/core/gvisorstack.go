@@ -24,7 +24,8 @@ func (i id) UniqueID() uint64 {
 	return 1
 }
 
-func NewStack(ctx context.Context, tun stack.LinkEndpoint) *stack.Stack {
+// NewStack tcp and udp of stack are forwarded to gvisor forwarders of traffic manager which tun device connects to
+func NewStack(ctx context.Context, tun stack.LinkEndpoint, tcpForwardAddr, udpForwardAddr string) *stack.Stack {
 	s := stack.New(stack.Options{
 		NetworkProtocols: []stack.NetworkProtocolFactory{
 			ipv4.NewProtocol,
@@ -43,8 +44,8 @@ func NewStack(ctx context.Context, tun stack.LinkEndpoint) *stack.Stack {
 		UniqueID:   id{},
 	})
 	// set handler for TCP UDP ICMP
-	s.SetTransportProtocolHandler(tcp.ProtocolNumber, TCPForwarder(s))
-	s.SetTransportProtocolHandler(udp.ProtocolNumber, UDPForwarder(s))
+	s.SetTransportProtocolHandler(tcp.ProtocolNumber, TCPForwarder(s, tcpForwardAddr))
+	s.SetTransportProtocolHandler(udp.ProtocolNumber, UDPForwarder(s, udpForwardAddr))
 
 	s.SetRouteTable([]tcpip.Route{
 		{
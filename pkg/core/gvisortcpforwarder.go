@@ -18,9 +18,8 @@ import (
 	"github.com/wencaiwulue/kubevpn/pkg/config"
 )
 
-var GvisorTCPForwardAddr string
-
-func TCPForwarder(s *stack.Stack) func(stack.TransportEndpointID, *stack.PacketBuffer) bool {
+// TCPForwarder forward tcp connections of gvisor stack to forwardAddr, eg: tcp://127.0.0.1:10801?key=<tunnel key token>
+func TCPForwarder(s *stack.Stack, forwardAddr string) func(stack.TransportEndpointID, *stack.PacketBuffer) bool {
 	return tcp.NewForwarder(s, 0, 100000, func(request *tcp.ForwarderRequest) {
 		defer request.Complete(false)
 		id := request.ID()
@@ -28,14 +27,19 @@ func TCPForwarder(s *stack.Stack) func(stack.TransportEndpointID, *stack.PacketB
 			id.LocalPort, id.LocalAddress.String(), id.RemotePort, id.RemoteAddress.String(),
 		)
 
-		node, err := ParseNode(GvisorTCPForwardAddr)
+		node, err := ParseNode(forwardAddr)
+		if err != nil {
+			log.Debugf("[TUN-TCP] Error: can not parse gvisor tcp forward addr %s: %v", forwardAddr, err)
+			return
+		}
+		transporter, err := protocols.newTransporter(node)
 		if err != nil {
-			log.Debugf("[TUN-TCP] Error: can not parse gvisor tcp forward addr %s: %v", GvisorTCPForwardAddr, err)
+			log.Debugf("[TUN-TCP] Error: %v", err)
 			return
 		}
 		node.Client = &Client{
 			Connector:   GvisorTCPTunnelConnector(),
-			Transporter: TCPTransporter(),
+			Transporter: transporter,
 		}
 		forwardChain := NewChain(5, node)
 
@@ -44,6 +48,11 @@ func TCPForwarder(s *stack.Stack) func(stack.TransportEndpointID, *stack.PacketB
 			log.Debugf("[TUN-TCP] Error: failed to dial remote conn: %v", err)
 			return
 		}
+		if err = writeTunnelHandshake(remote, node.Get("key")); err != nil {
+			log.Debugf("[TUN-TCP] Error: failed to write handshake: %v", err)
+			_ = remote.Close()
+			return
+		}
 		if err = WriteProxyInfo(remote, id); err != nil {
 			log.Debugf("[TUN-TCP] Error: failed to write proxy info: %v", err)
 			return
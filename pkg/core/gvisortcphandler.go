@@ -48,6 +48,13 @@ func GvisorTCPHandler() Handler {
 func (h *gvisorTCPHandler) Handle(ctx context.Context, tcpConn net.Conn) {
 	defer tcpConn.Close()
 	log.Debugf("[TUN-TCP] %s -> %s", tcpConn.RemoteAddr(), tcpConn.LocalAddr())
+	// forwarder dial any address, only client which owns tunnel key can use it
+	if TunnelKeys.Enabled() {
+		if _, err := readTunnelHandshake(tcpConn); err != nil {
+			log.Debugf("[TUN-TCP] Error: reject %s: %v", tcpConn.RemoteAddr(), err)
+			return
+		}
+	}
 	// 1, get proxy info
 	endpointID, err := ParseProxyInfo(tcpConn)
 	if err != nil {
@@ -14,9 +14,8 @@ import (
 	"github.com/wencaiwulue/kubevpn/pkg/config"
 )
 
-var GvisorUDPForwardAddr string
-
-func UDPForwarder(s *stack.Stack) func(id stack.TransportEndpointID, pkt *stack.PacketBuffer) bool {
+// UDPForwarder forward udp of gvisor stack to forwardAddr, eg: tcp://127.0.0.1:10802?key=<tunnel key token>
+func UDPForwarder(s *stack.Stack, forwardAddr string) func(id stack.TransportEndpointID, pkt *stack.PacketBuffer) bool {
 	return udp.NewForwarder(s, func(request *udp.ForwarderRequest) {
 		endpointID := request.ID()
 		log.Debugf("[TUN-UDP] Debug: LocalPort: %d, LocalAddress: %s, RemotePort: %d, RemoteAddress %s",
@@ -29,14 +28,19 @@ func UDPForwarder(s *stack.Stack) func(id stack.TransportEndpointID, pkt *stack.
 			return
 		}
 
-		node, err := ParseNode(GvisorUDPForwardAddr)
+		node, err := ParseNode(forwardAddr)
+		if err != nil {
+			log.Debugf("[TUN-UDP] Error: parse gviosr udp forward addr %s: %v", forwardAddr, err)
+			return
+		}
+		transporter, err := protocols.newTransporter(node)
 		if err != nil {
-			log.Debugf("[TUN-UDP] Error: parse gviosr udp forward addr %s: %v", GvisorUDPForwardAddr, err)
+			log.Debugf("[TUN-UDP] Error: %v", err)
 			return
 		}
 		node.Client = &Client{
 			Connector:   GvisorUDPOverTCPTunnelConnector(endpointID),
-			Transporter: TCPTransporter(),
+			Transporter: transporter,
 		}
 		forwardChain := NewChain(5, node)
 
@@ -46,6 +50,11 @@ func UDPForwarder(s *stack.Stack) func(id stack.TransportEndpointID, pkt *stack.
 			log.Debugf("[TUN-UDP] Error: can not get conn: %v", err)
 			return
 		}
+		if err = writeTunnelHandshake(c, node.Get("key")); err != nil {
+			log.Debugf("[TUN-UDP] Error: can not write handshake: %v", err)
+			_ = c.Close()
+			return
+		}
 		if err = WriteProxyInfo(c, endpointID); err != nil {
 			log.Debugf("[TUN-UDP] Error: can not write proxy info: %v", err)
 			return
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	peerTxPacketsDesc = prometheus.NewDesc("kubevpn_peer_tx_packets_total", "Packets sent to tunnel peer", []string{"peer"}, nil)
+	peerTxBytesDesc   = prometheus.NewDesc("kubevpn_peer_tx_bytes_total", "Bytes sent to tunnel peer", []string{"peer"}, nil)
+	peerRxPacketsDesc = prometheus.NewDesc("kubevpn_peer_rx_packets_total", "Packets received from tunnel peer", []string{"peer"}, nil)
+	peerRxBytesDesc   = prometheus.NewDesc("kubevpn_peer_rx_bytes_total", "Bytes received from tunnel peer", []string{"peer"}, nil)
+	peerDropsDesc     = prometheus.NewDesc("kubevpn_peer_drops_total", "Packets dropped of tunnel peer", []string{"peer"}, nil)
+	peerLastSeenDesc  = prometheus.NewDesc("kubevpn_peer_last_seen_seconds", "Unix time of last packet received from tunnel peer", []string{"peer"}, nil)
+	peerRTTDesc       = prometheus.NewDesc("kubevpn_peer_rtt_seconds", "Round trip time of heartbeats to traffic manager", []string{"peer"}, nil)
+)
+
+type peerStatsCollector struct {
+	registry *PeerStatsRegistry
+}
+
+func (c *peerStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		peerTxPacketsDesc, peerTxBytesDesc, peerRxPacketsDesc, peerRxBytesDesc, peerDropsDesc, peerLastSeenDesc, peerRTTDesc,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *peerStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stat := range c.registry.List() {
+		ch <- prometheus.MustNewConstMetric(peerTxPacketsDesc, prometheus.CounterValue, float64(stat.TxPackets), stat.IP)
+		ch <- prometheus.MustNewConstMetric(peerTxBytesDesc, prometheus.CounterValue, float64(stat.TxBytes), stat.IP)
+		ch <- prometheus.MustNewConstMetric(peerRxPacketsDesc, prometheus.CounterValue, float64(stat.RxPackets), stat.IP)
+		ch <- prometheus.MustNewConstMetric(peerRxBytesDesc, prometheus.CounterValue, float64(stat.RxBytes), stat.IP)
+		ch <- prometheus.MustNewConstMetric(peerDropsDesc, prometheus.CounterValue, float64(stat.Drops), stat.IP)
+		if !stat.LastSeen.IsZero() {
+			ch <- prometheus.MustNewConstMetric(peerLastSeenDesc, prometheus.GaugeValue, float64(stat.LastSeen.Unix()), stat.IP)
+		}
+		if stat.RTT != 0 {
+			ch <- prometheus.MustNewConstMetric(peerRTTDesc, prometheus.GaugeValue, stat.RTT.Seconds(), stat.IP)
+		}
+	}
+}
+
+// MetricsHandler prometheus handler of peer stats
+func MetricsHandler(registry *PeerStatsRegistry) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(&peerStatsCollector{registry: registry})
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
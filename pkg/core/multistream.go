@@ -0,0 +1,357 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/util"
+)
+
+// DefaultStreams default stream count of protocol mtcp
+const DefaultStreams = 4
+
+const (
+	// streamHello first frame of every stream of mtcp, it is never a valid ip packet, sealed packet or session hello
+	streamHello = 0xfe
+	// hello: 0xfe, group id
+	streamHelloSize = 1 + sessionIDSize
+)
+
+func isStreamHello(b []byte) bool {
+	return len(b) == streamHelloSize && b[0] == streamHello
+}
+
+type multiStreamConnector struct {
+	streams   int
+	connector Connector
+}
+
+// MultiStreamConnector open streams tcp connections to same address, every connection over port-forward is a
+// separate stream, packets of same flow always go through same stream, so one stalled stream will not block others.
+// every stream starts with stream hello of same group id, server groups them as one client conn and routes replies
+// by flow hash too
+func MultiStreamConnector(streams int, connector Connector) Connector {
+	if streams <= 0 {
+		streams = DefaultStreams
+	}
+	return &multiStreamConnector{streams: streams, connector: connector}
+}
+
+func (c *multiStreamConnector) ConnectContext(ctx context.Context, conn net.Conn) (net.Conn, error) {
+	// new group every time, server replaces routes of old group which streams are broken
+	hello := make([]byte, streamHelloSize)
+	hello[0] = streamHello
+	if _, err := rand.Read(hello[1:]); err != nil {
+		return nil, err
+	}
+	var conns []net.Conn
+	var closeAll = func() {
+		for _, cc := range conns {
+			_ = cc.Close()
+		}
+	}
+	// hello is written before connector, so it is not sealed by tunnel key
+	if err := newDatagramPacket(hello).Write(conn); err != nil {
+		return nil, err
+	}
+	first, err := c.connector.ConnectContext(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	conns = append(conns, first)
+	for i := 1; i < c.streams; i++ {
+		var cc net.Conn
+		cc, err = TCPTransporter().Dial(ctx, conn.RemoteAddr().String())
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		if err = newDatagramPacket(hello).Write(cc); err != nil {
+			_ = cc.Close()
+			closeAll()
+			return nil, err
+		}
+		var stream net.Conn
+		stream, err = c.connector.ConnectContext(ctx, cc)
+		if err != nil {
+			_ = cc.Close()
+			closeAll()
+			return nil, err
+		}
+		conns = append(conns, stream)
+	}
+	log.Debugf("[mtcp] open %d streams to %s", len(conns), conn.RemoteAddr())
+	return newMultiStreamConn(ctx, conns), nil
+}
+
+type multiStreamPacket struct {
+	data   []byte
+	length int
+	from   net.Addr
+}
+
+// multiStreamConn fake udp conn over multiple tcp streams
+type multiStreamConn struct {
+	net.Conn
+	streams []net.Conn
+	readCh  chan *multiStreamPacket
+	errCh   chan error
+	once    sync.Once
+	closed  chan struct{}
+}
+
+func newMultiStreamConn(ctx context.Context, streams []net.Conn) *multiStreamConn {
+	c := &multiStreamConn{
+		Conn:    streams[0],
+		streams: streams,
+		readCh:  make(chan *multiStreamPacket, MaxSize),
+		errCh:   make(chan error, len(streams)),
+		closed:  make(chan struct{}),
+	}
+	for _, stream := range streams {
+		go c.readFrom(ctx, stream.(net.PacketConn))
+	}
+	return c
+}
+
+func (c *multiStreamConn) readFrom(ctx context.Context, stream net.PacketConn) {
+	for {
+		b := config.LPool.Get().([]byte)[:]
+		n, from, err := stream.ReadFrom(b[:])
+		if err != nil {
+			config.LPool.Put(b[:])
+			// any stream broken, close all, tun client will reconnect
+			select {
+			case c.errCh <- err:
+			default:
+			}
+			_ = c.Close()
+			return
+		}
+		select {
+		case c.readCh <- &multiStreamPacket{data: b, length: n, from: from}:
+		case <-c.closed:
+			config.LPool.Put(b[:])
+			return
+		case <-ctx.Done():
+			config.LPool.Put(b[:])
+			return
+		}
+	}
+}
+
+func (c *multiStreamConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	select {
+	case p := <-c.readCh:
+		n := copy(b, p.data[:p.length])
+		config.LPool.Put(p.data[:])
+		return n, p.from, nil
+	case err := <-c.errCh:
+		return 0, nil, err
+	case <-c.closed:
+		return 0, nil, net.ErrClosed
+	}
+}
+
+func (c *multiStreamConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	stream := c.streams[flowHash(b)%uint32(len(c.streams))]
+	return stream.(net.PacketConn).WriteTo(b, addr)
+}
+
+func (c *multiStreamConn) Close() error {
+	var errs []error
+	c.once.Do(func() {
+		close(c.closed)
+		for _, stream := range c.streams {
+			if err := stream.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// flowHash hash of ip packet protocol, source/destination ip and port, it is same for both directions of flow, so
+// replies of server go through same stream as requests of client
+func flowHash(packet []byte) uint32 {
+	h := fnv.New32a()
+	if len(packet) == 0 {
+		return 0
+	}
+	var protocol byte
+	var src, dst, payload []byte
+	if util.IsIPv4(packet) && len(packet) >= 20 {
+		ihl := int(packet[0]&0x0f) * 4
+		protocol = packet[9]
+		src, dst = packet[12:16], packet[16:20]
+		if len(packet) > ihl {
+			payload = packet[ihl:]
+		}
+	} else if util.IsIPv6(packet) && len(packet) >= 40 {
+		protocol = packet[6]
+		src, dst = packet[8:24], packet[24:40]
+		payload = packet[40:]
+	} else {
+		return 0
+	}
+	src, dst = append([]byte(nil), src...), append([]byte(nil), dst...)
+	// tcp and udp port, ignore ipv6 extension header
+	if (protocol == 6 || protocol == 17) && len(payload) >= 4 {
+		src, dst = append(src, payload[:2]...), append(dst, payload[2:4]...)
+	}
+	if bytes.Compare(src, dst) > 0 {
+		src, dst = dst, src
+	}
+	_, _ = h.Write(src)
+	_, _ = h.Write(dst)
+	_, _ = h.Write([]byte{protocol})
+	return h.Sum32()
+}
+
+// StreamGroups streams of mtcp clients in traffic manager
+var StreamGroups = &streamGroupRegistry{groups: map[string]*streamGroup{}}
+
+type streamGroupRegistry struct {
+	lock sync.Mutex
+	// map[group id]*streamGroup
+	groups map[string]*streamGroup
+}
+
+// attach tcp conn to group of hello, init is called once group is created
+func (r *streamGroupRegistry) attach(hello []byte, conn net.Conn, init func(g *streamGroup)) (*streamGroup, error) {
+	if !isStreamHello(hello) {
+		return nil, fmt.Errorf("invalid stream hello")
+	}
+	id := hex.EncodeToString(hello[1:])
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	g, ok := r.groups[id]
+	if !ok {
+		g = &streamGroup{id: id}
+		init(g)
+		r.groups[id] = g
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.streams = append(g.streams, conn)
+	log.Debugf("[mtcp] stream %s joins group %s, streams: %d", conn.RemoteAddr(), id, len(g.streams))
+	return g, nil
+}
+
+// detach tcp conn of stream is gone, last is true if all streams of group are gone
+func (r *streamGroupRegistry) detach(g *streamGroup, conn net.Conn) (last bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for i, stream := range g.streams {
+		if stream == conn {
+			g.streams = append(g.streams[:i], g.streams[i+1:]...)
+			break
+		}
+	}
+	if len(g.streams) != 0 {
+		return false
+	}
+	delete(r.groups, g.id)
+	return true
+}
+
+// streamGroup server side of mtcp client, it is stable conn of client in route table, packet is written to stream
+// which is picked by flow hash of packet
+type streamGroup struct {
+	id      string
+	lock    sync.RWMutex
+	streams []net.Conn
+
+	// route conn of client in route table, it is group or group wrapped by authConn
+	route net.Conn
+}
+
+// pick stream of flow, group itself if all streams are gone
+func (g *streamGroup) pick(packet []byte) net.Conn {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if len(g.streams) == 0 {
+		return g
+	}
+	return g.streams[flowHash(packet)%uint32(len(g.streams))]
+}
+
+func (g *streamGroup) first() net.Conn {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if len(g.streams) == 0 {
+		return nil
+	}
+	return g.streams[0]
+}
+
+func (g *streamGroup) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("read from stream of group %s", g.id)
+}
+
+// Write frame which flow is unknown goes through first stream
+func (g *streamGroup) Write(b []byte) (int, error) {
+	if conn := g.first(); conn != nil {
+		return conn.Write(b)
+	}
+	return 0, net.ErrClosed
+}
+
+func (g *streamGroup) Close() error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	var errs []error
+	for _, stream := range g.streams {
+		if err := stream.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *streamGroup) LocalAddr() net.Addr {
+	if conn := g.first(); conn != nil {
+		return conn.LocalAddr()
+	}
+	return nil
+}
+
+func (g *streamGroup) RemoteAddr() net.Addr {
+	if conn := g.first(); conn != nil {
+		return conn.RemoteAddr()
+	}
+	return nil
+}
+
+func (g *streamGroup) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (g *streamGroup) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (g *streamGroup) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// flowConn stream of group which flow of packet goes through, or conn itself if it is not group
+func flowConn(conn net.Conn, packet []byte) net.Conn {
+	if g, ok := conn.(*streamGroup); ok {
+		return g.pick(packet)
+	}
+	return conn
+}
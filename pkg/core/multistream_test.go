@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlowHash(t *testing.T) {
+	// ipv4 tcp 223.254.0.100:1234 -> 10.0.0.1:80
+	packet := make([]byte, 40)
+	packet[0] = 0x45
+	packet[9] = 6
+	copy(packet[12:16], []byte{223, 254, 0, 100})
+	copy(packet[16:20], []byte{10, 0, 0, 1})
+	copy(packet[20:24], []byte{0x04, 0xd2, 0x00, 0x50})
+	h := flowHash(packet)
+
+	other := make([]byte, 40)
+	copy(other, packet)
+	other[21] = 0xd3
+	if flowHash(other) == h {
+		t.Fatalf("different flow should has different hash")
+	}
+	// payload not affect hash
+	packet[30] = 1
+	if flowHash(packet) != h {
+		t.Fatalf("same flow should has same hash")
+	}
+	// reply 10.0.0.1:80 -> 223.254.0.100:1234
+	reply := make([]byte, 40)
+	reply[0] = 0x45
+	reply[9] = 6
+	copy(reply[12:16], []byte{10, 0, 0, 1})
+	copy(reply[16:20], []byte{223, 254, 0, 100})
+	copy(reply[20:24], []byte{0x00, 0x50, 0x04, 0xd2})
+	if flowHash(reply) != h {
+		t.Fatalf("reply of flow should has same hash")
+	}
+	if flowHash(nil) != 0 {
+		t.Fatalf("invalid packet hash should be 0")
+	}
+}
+
+func TestMultiStreamConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	var accepted = make(chan struct{}, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- struct{}{}
+			// echo every datagram
+			go func() {
+				defer conn.Close()
+				b := make([]byte, 1<<16)
+				for {
+					dgram, err := readDatagramPacket(conn, b)
+					if err != nil {
+						return
+					}
+					if isStreamHello(dgram.Data[:dgram.DataLength]) {
+						continue
+					}
+					if err = dgram.Write(conn); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn, err := TCPTransporter().Dial(ctx, ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc, err := MultiStreamConnector(3, UDPOverTCPTunnelConnector()).ConnectContext(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	for i := 0; i < 3; i++ {
+		select {
+		case <-accepted:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("expect 3 streams, but got %d", i)
+		}
+	}
+
+	packetConn := cc.(net.PacketConn)
+	packet := make([]byte, 40)
+	packet[0] = 0x45
+	packet[9] = 17
+	if _, err = packetConn.WriteTo(packet, nil); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 1<<16)
+	n, _, err := packetConn.ReadFrom(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(packet) {
+		t.Fatalf("expect %d bytes, but got %d", len(packet), n)
+	}
+}
+
+func TestStreamGroup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	h := &fakeUdpHandler{connNAT: &sync.Map{}, ch: make(chan *datagramPacket, 10)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go h.Handle(ctx, conn)
+		}
+	}()
+
+	conn, err := TCPTransporter().Dial(ctx, ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc, err := MultiStreamConnector(3, UDPOverTCPTunnelConnector()).ConnectContext(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packetConn := cc.(net.PacketConn)
+	// udp 223.254.0.100:port -> 10.0.0.1:53, flows go through different streams
+	var route net.Conn
+	for port := byte(1); port <= 16; port++ {
+		packet := make([]byte, 28)
+		packet[0] = 0x45
+		packet[9] = 17
+		copy(packet[12:16], []byte{223, 254, 0, 100})
+		copy(packet[16:20], []byte{10, 0, 0, 1})
+		copy(packet[20:24], []byte{0x10, port, 0x00, 0x35})
+		if _, err = packetConn.WriteTo(packet, nil); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-h.ch:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("server not receive packet")
+		}
+		value, ok := h.connNAT.Load("223.254.0.100")
+		if !ok {
+			t.Fatalf("expect route of client")
+		}
+		if route != nil && route != value.(net.Conn) {
+			t.Fatalf("expect route of client not changed between streams")
+		}
+		route = value.(net.Conn)
+	}
+	group, ok := route.(*streamGroup)
+	if !ok {
+		t.Fatalf("expect route is stream group, but got %T", route)
+	}
+	if group.first() == nil {
+		t.Fatalf("expect streams of group")
+	}
+
+	// reply 10.0.0.1:53 -> 223.254.0.100:port
+	reply := make([]byte, 28)
+	reply[0] = 0x45
+	reply[9] = 17
+	copy(reply[12:16], []byte{10, 0, 0, 1})
+	copy(reply[16:20], []byte{223, 254, 0, 100})
+	copy(reply[20:24], []byte{0x00, 0x35, 0x10, 0x01})
+	if err = writeDatagramPacket(route, reply); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 1<<16)
+	n, _, err := packetConn.ReadFrom(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(reply) {
+		t.Fatalf("expect %d bytes, but got %d", len(reply), n)
+	}
+
+	// routes are removed after all streams are gone
+	_ = cc.Close()
+	for i := 0; ; i++ {
+		if _, ok = h.connNAT.Load("223.254.0.100"); !ok {
+			break
+		}
+		if i > 50 {
+			t.Fatalf("expect route of client removed")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+}
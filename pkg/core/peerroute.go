@@ -0,0 +1,292 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+// peerDialRetry do not dial replica again for a while if it is unreachable
+const peerDialRetry = time.Second * 5
+
+// PeerRoutes routes of clients which are shared between replicas of traffic manager. every replica publishes tun ip of
+// clients which connect to it, packets to clients of other replicas are forwarded to peer port of that replica
+var PeerRoutes = &peerRouteTable{
+	routes:  map[string]string{},
+	local:   map[string]struct{}{},
+	changed: make(chan struct{}, 1),
+	forwarder: &peerForwarder{
+		conns:  map[string]*peerForwardConn{},
+		failed: map[string]time.Time{},
+	},
+}
+
+type peerRouteTable struct {
+	lock sync.RWMutex
+	// self peer address of this replica, empty means routes are not shared
+	self string
+	// map[tun ip]peer address of replica
+	routes map[string]string
+	// tun ip of clients which connect to this replica
+	local     map[string]struct{}
+	changed   chan struct{}
+	forwarder *peerForwarder
+}
+
+// Enable share routes, self is peer address of this replica, eg: 10.244.0.12:10804
+func (t *peerRouteTable) Enable(self string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.self = self
+}
+
+func (t *peerRouteTable) Self() string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.self
+}
+
+// Update replace routes, routes is map[tun ip]peer address of replica
+func (t *peerRouteTable) Update(routes map[string]string) {
+	if routes == nil {
+		routes = map[string]string{}
+	}
+	t.lock.Lock()
+	t.routes = routes
+	self := t.self
+	t.lock.Unlock()
+	for ip, addr := range routes {
+		if addr != self {
+			evictSession(ip, addr)
+		}
+	}
+}
+
+// evictSession client reconnects to other replica, close its detached session in this replica, otherwise packets to
+// it are buffered into the dead session instead of being forwarded to that replica until session is expired
+func evictSession(ip, addr string) {
+	value, ok := RouteConnNAT.Load(ip)
+	if !ok {
+		return
+	}
+	if s := sessionOf(value.(net.Conn)); s != nil && s.isDetached() {
+		log.Debugf("[peer] client %s moves to %s, close session %s", ip, addr, s.id)
+		Sessions.remove(s)
+		_ = s.Close()
+	}
+}
+
+// Local tun ip of clients which connect to this replica
+func (t *peerRouteTable) Local() []string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var result = make([]string, 0, len(t.local))
+	for ip := range t.local {
+		result = append(result, ip)
+	}
+	return result
+}
+
+// Changed notify once clients of this replica changed
+func (t *peerRouteTable) Changed() <-chan struct{} {
+	return t.changed
+}
+
+func (t *peerRouteTable) addLocal(ip net.IP) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.local[ip.String()]; t.self == "" || ok {
+		return
+	}
+	t.local[ip.String()] = struct{}{}
+	t.notify()
+}
+
+func (t *peerRouteTable) removeLocal(ip net.IP) {
+	t.forwarder.close(ip)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.local[ip.String()]; !ok {
+		return
+	}
+	delete(t.local, ip.String())
+	t.notify()
+}
+
+func (t *peerRouteTable) notify() {
+	select {
+	case t.changed <- struct{}{}:
+	default:
+	}
+}
+
+// lookup peer address of replica which client connects to, empty if client is not connected to other replica
+func (t *peerRouteTable) lookup(ip net.IP) string {
+	if !config.CIDR.Contains(ip) && !config.CIDR6.Contains(ip) {
+		return ""
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if t.self == "" {
+		return ""
+	}
+	if _, ok := t.local[ip.String()]; ok {
+		return ""
+	}
+	if addr := t.routes[ip.String()]; addr != t.self {
+		return addr
+	}
+	return ""
+}
+
+// forward packet to client of other replica, owner is the client whose tunnel key seals packet, it is source client
+// of packet from client, or destination client of packet from tun device
+func (t *peerRouteTable) forward(addr string, owner net.IP, e *DataElem) {
+	t.forwarder.forward(addr, owner, e)
+}
+
+// peerForwarder one conn per client and replica, packet is sealed by tunnel key of owner client, so replica which
+// receives it can authenticate it like packet from client directly, and route replies back through the same conn
+type peerForwarder struct {
+	lock sync.Mutex
+	// map[client/replica]*peerForwardConn
+	conns map[string]*peerForwardConn
+	// map[replica]time of last dial failure
+	failed map[string]time.Time
+}
+
+type peerForwardConn struct {
+	client string
+	conn   net.PacketConn
+	ch     chan *DataElem
+}
+
+// forward never blocks, conn is dialed asynchronously, packets are dropped if replica is unreachable
+func (f *peerForwarder) forward(addr string, owner net.IP, e *DataElem) {
+	client := PeerStats.Key(owner)
+	key := client + "/" + addr
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.conns[key]
+	if !ok {
+		if t, failed := f.failed[addr]; failed && time.Since(t) < peerDialRetry {
+			PeerStats.Drop(e.dst)
+			config.LPool.Put(e.data[:])
+			return
+		}
+		c = &peerForwardConn{client: client, ch: make(chan *DataElem, clientQueueSize)}
+		f.conns[key] = c
+		go f.dial(key, addr, owner, c)
+	}
+	select {
+	case c.ch <- e:
+	default:
+		PeerStats.Drop(e.dst)
+		config.LPool.Put(e.data[:])
+	}
+}
+
+func (f *peerForwarder) dial(key, addr string, owner net.IP, c *peerForwardConn) {
+	conn, err := dialPeer(addr, owner)
+	if err != nil {
+		log.Debugf("[peer] can not forward packets of %s to %s: %v", c.client, addr, err)
+		f.lock.Lock()
+		f.failed[addr] = time.Now()
+		f.lock.Unlock()
+		f.remove(key, c)
+		return
+	}
+	f.lock.Lock()
+	c.conn = conn
+	delete(f.failed, addr)
+	f.lock.Unlock()
+	log.Debugf("[peer] forward packets of %s to %s", c.client, addr)
+	go f.read(key, c)
+	f.write(key, c)
+}
+
+// dialPeer dial peer port of other replica, packets are sealed by tunnel key of owner client if authentication is enabled
+func dialPeer(addr string, owner net.IP) (net.PacketConn, error) {
+	var cipher *tunnelCipher
+	if TunnelKeys.Enabled() {
+		if cipher = TunnelKeys.lookup(owner); cipher == nil {
+			return nil, fmt.Errorf("tunnel key of %s not found", owner)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	tcpConn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &fakeUDPTunnelConn{ctx: context.Background(), Conn: tcpConn}
+	if cipher != nil {
+		key := &atomic.Pointer[tunnelCipher]{}
+		key.Store(cipher)
+		return &sealedConn{Conn: conn, key: key}, nil
+	}
+	return conn, nil
+}
+
+func (f *peerForwarder) write(key string, c *peerForwardConn) {
+	defer f.remove(key, c)
+	for e := range c.ch {
+		_, err := c.conn.WriteTo(e.data[:e.length], nil)
+		config.LPool.Put(e.data[:])
+		if err != nil {
+			log.Debugf("[peer] failed to forward packet of %s: %v", c.client, err)
+			PeerStats.Drop(e.dst)
+			return
+		}
+		PeerStats.Tx(e.dst, e.length)
+	}
+}
+
+// read replies from clients of other replica, route them like packets from local clients
+func (f *peerForwarder) read(key string, c *peerForwardConn) {
+	defer f.remove(key, c)
+	for {
+		b := config.LPool.Get().([]byte)[:]
+		n, _, err := c.conn.ReadFrom(b[:])
+		if err != nil {
+			config.LPool.Put(b[:])
+			return
+		}
+		Chan <- &datagramPacket{DataLength: uint16(n), Data: b[:]}
+	}
+}
+
+func (f *peerForwarder) remove(key string, c *peerForwardConn) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.conns[key] == c {
+		delete(f.conns, key)
+		close(c.ch)
+	}
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
+
+// close conns of client once it is gone
+func (f *peerForwarder) close(ip net.IP) {
+	client := PeerStats.Key(ip)
+	f.lock.Lock()
+	var conns = make(map[string]*peerForwardConn)
+	for key, c := range f.conns {
+		if c.client == client {
+			conns[key] = c
+		}
+	}
+	f.lock.Unlock()
+	for key, c := range conns {
+		f.remove(key, c)
+	}
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPeerRoutes(t *testing.T) {
+	r := &peerRouteTable{
+		routes:    map[string]string{},
+		local:     map[string]struct{}{},
+		changed:   make(chan struct{}, 1),
+		forwarder: &peerForwarder{conns: map[string]*peerForwardConn{}, failed: map[string]time.Time{}},
+	}
+	r.addLocal(net.ParseIP("223.254.0.100"))
+	if len(r.Local()) != 0 {
+		t.Fatalf("expect no local client if routes are not shared")
+	}
+	r.Enable("10.244.0.12:10804")
+	r.Update(map[string]string{
+		"223.254.0.100": "10.244.0.12:10804",
+		"223.254.0.101": "10.244.0.13:10804",
+		"223.254.0.102": "10.244.0.13:10804",
+	})
+	r.addLocal(net.ParseIP("223.254.0.102"))
+	select {
+	case <-r.Changed():
+	default:
+		t.Fatalf("expect notify once local client changed")
+	}
+	for ip, expect := range map[string]string{
+		"223.254.0.100": "",
+		"223.254.0.101": "10.244.0.13:10804",
+		// client reconnects to this replica
+		"223.254.0.102": "",
+		"223.254.0.103": "",
+		"10.96.0.10":    "",
+	} {
+		if addr := r.lookup(net.ParseIP(ip)); addr != expect {
+			t.Fatalf("expect route of %s is %q, but got %q", ip, expect, addr)
+		}
+	}
+	r.removeLocal(net.ParseIP("223.254.0.102"))
+	if addr := r.lookup(net.ParseIP("223.254.0.102")); addr != "10.244.0.13:10804" {
+		t.Fatalf("expect route to other replica once local client is gone, but got %q", addr)
+	}
+}
+
+func TestEvictMovedSession(t *testing.T) {
+	r := &peerRouteTable{
+		self:      "10.244.0.12:10804",
+		routes:    map[string]string{},
+		local:     map[string]struct{}{},
+		changed:   make(chan struct{}, 1),
+		forwarder: &peerForwarder{conns: map[string]*peerForwardConn{}, failed: map[string]time.Time{}},
+	}
+	var closed bool
+	s := &serverSession{id: "moved", onClose: func() { closed = true }}
+	s.route = s
+	Sessions.lock.Lock()
+	Sessions.sessions[s.id] = s
+	Sessions.lock.Unlock()
+	RouteConnNAT.Store("223.254.0.104", s.route)
+	defer RouteConnNAT.Delete("223.254.0.104")
+
+	r.Update(map[string]string{"223.254.0.104": "10.244.0.12:10804"})
+	if closed {
+		t.Fatalf("expect session is kept while client is routed to this replica")
+	}
+	// client resumes to other replica
+	r.Update(map[string]string{"223.254.0.104": "10.244.0.13:10804"})
+	if !closed {
+		t.Fatalf("expect detached session is closed once client moves to other replica")
+	}
+	Sessions.lock.Lock()
+	_, ok := Sessions.sessions[s.id]
+	Sessions.lock.Unlock()
+	if ok {
+		t.Fatalf("expect session is removed")
+	}
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/util"
+)
+
+const (
+	// MinMTU minimum mtu of ipv4, path mtu probing starts from it
+	MinMTU = 576
+	// minIPv6MTU ipv6 packet smaller than it must not be fragmented
+	minIPv6MTU = 1280
+	// pmtuProbeID icmp id of path mtu probes
+	pmtuProbeID      = 3843
+	pmtuProbeTimeout = time.Millisecond * 500
+	pmtuProbeRetries = 2
+)
+
+// PathMTUs path mtu of tunnels in client, one per tun device, key is tun ipv4. connections to different clusters
+// probe and apply path mtu of their own tunnel
+var PathMTUs = &pathMTUStore{tunnels: map[string]*pathMTU{}}
+
+type pathMTUStore struct {
+	lock sync.RWMutex
+	// map[tun ip]*pathMTU
+	tunnels map[string]*pathMTU
+}
+
+func (s *pathMTUStore) add(ip net.IP, p *pathMTU) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tunnels[ip.String()] = p
+}
+
+// remove only if tunnel is not replaced by a new one
+func (s *pathMTUStore) remove(ip net.IP, p *pathMTU) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.tunnels[ip.String()] == p {
+		delete(s.tunnels, ip.String())
+	}
+}
+
+func (s *pathMTUStore) get(ip net.IP) *pathMTU {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.tunnels[ip.String()]
+}
+
+// Probe probe path mtu of tunnel which tun ip is src
+func (s *pathMTUStore) Probe(ctx context.Context, src, dst net.IP, max int) (int, error) {
+	p := s.get(src)
+	if p == nil {
+		return 0, fmt.Errorf("tunnel of %s is not ready", src)
+	}
+	return p.Probe(ctx, src, dst, max)
+}
+
+// Set path mtu of tunnel which tun ip is src
+func (s *pathMTUStore) Set(src net.IP, mtu int) {
+	if p := s.get(src); p != nil {
+		p.Set(mtu)
+	}
+}
+
+// pathMTU path mtu of tunnel, it is probed at connect time, 0 means unknown. client drop packets which are larger than
+// it and reply icmp fragmentation needed (ipv4) or packet too big (ipv6) to tun device like a router does,
+// so local tcp stack lower mss instead of hanging on large responses
+type pathMTU struct {
+	mtu atomic.Int32
+	// tunnel inbound of client device, probes are sent through it
+	in      chan<- *DataElem
+	lock    sync.Mutex
+	replies chan pmtuReply
+}
+
+func newPathMTU(in chan<- *DataElem) *pathMTU {
+	return &pathMTU{in: in, replies: make(chan pmtuReply, 16)}
+}
+
+// pmtuReply size is total length of probe, nextHop is mtu of next hop if probe is too big
+type pmtuReply struct {
+	size    int
+	ok      bool
+	nextHop int
+}
+
+func (p *pathMTU) Get() int {
+	return int(p.mtu.Load())
+}
+
+func (p *pathMTU) Set(mtu int) {
+	p.mtu.Store(int32(mtu))
+}
+
+// Probe find path mtu from src to dst through tunnel by sending icmp echo with DF, from max to MinMTU
+func (p *pathMTU) Probe(ctx context.Context, src, dst net.IP, max int) (int, error) {
+	if src.To4() == nil || dst.To4() == nil {
+		return 0, fmt.Errorf("only support probing ipv4 path mtu")
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var probe = func(size int) (*pmtuReply, error) {
+		packet, err := genPMTUProbePacket(src, dst, size)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < pmtuProbeRetries; i++ {
+			data := config.LPool.Get().([]byte)[:]
+			length := copy(data, packet)
+			p.in <- &DataElem{data: data[:], length: length, src: src, dst: dst}
+			timer := time.NewTimer(pmtuProbeTimeout)
+		wait:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, ctx.Err()
+				case r := <-p.replies:
+					if r.size == size {
+						timer.Stop()
+						return &r, nil
+					}
+				case <-timer.C:
+					break wait
+				}
+			}
+		}
+		// no reply, maybe dropped silently because it is too big
+		return &pmtuReply{size: size}, nil
+	}
+
+	r, err := probe(max)
+	if err != nil {
+		return 0, err
+	}
+	if r.ok {
+		return max, nil
+	}
+	if r, err = probe(MinMTU); err != nil {
+		return 0, err
+	} else if !r.ok {
+		return 0, fmt.Errorf("%s is unreachable", dst)
+	}
+	lo, hi := MinMTU, max-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if r, err = probe(mid); err != nil {
+			return 0, err
+		}
+		if r.ok {
+			lo = mid
+		} else if r.nextHop >= lo && r.nextHop < mid {
+			hi = r.nextHop
+		} else {
+			hi = mid - 1
+		}
+	}
+	log.Debugf("[pmtu] path mtu from %s to %s is %d", src, dst, lo)
+	return lo, nil
+}
+
+// handleReply consume echo reply or fragmentation needed of probes
+func (p *pathMTU) handleReply(packet []byte) bool {
+	if !util.IsIPv4(packet) || len(packet) < 20 || packet[9] != byte(layers.IPProtocolICMPv4) {
+		return false
+	}
+	icmp := packet[int(packet[0]&0x0f)*4:]
+	if len(icmp) < 8 {
+		return false
+	}
+	var r pmtuReply
+	switch {
+	case icmp[0] == layers.ICMPv4TypeEchoReply && binary.BigEndian.Uint16(icmp[4:6]) == pmtuProbeID:
+		r = pmtuReply{size: int(binary.BigEndian.Uint16(icmp[6:8])), ok: true}
+	case icmp[0] == layers.ICMPv4TypeDestinationUnreachable && icmp[1] == layers.ICMPv4CodeFragmentationNeeded:
+		// original ip header and first 8 bytes of probe
+		origin := icmp[8:]
+		if len(origin) < 20 || origin[9] != byte(layers.IPProtocolICMPv4) {
+			return false
+		}
+		ihl := int(origin[0]&0x0f) * 4
+		if len(origin) < ihl+8 || binary.BigEndian.Uint16(origin[ihl+4:ihl+6]) != pmtuProbeID {
+			return false
+		}
+		r = pmtuReply{size: int(binary.BigEndian.Uint16(origin[2:4])), nextHop: int(binary.BigEndian.Uint16(icmp[6:8]))}
+	default:
+		return false
+	}
+	select {
+	case p.replies <- r:
+	default:
+	}
+	return true
+}
+
+// tooBig returns icmp error if packet is larger than path mtu and can not be fragmented
+func (p *pathMTU) tooBig(packet []byte) []byte {
+	mtu := p.Get()
+	if mtu <= 0 || len(packet) <= mtu {
+		return nil
+	}
+	var b []byte
+	var err error
+	if util.IsIPv4(packet) && len(packet) >= 20 {
+		if layers.IPv4Flag(packet[6]>>5)&layers.IPv4DontFragment == 0 {
+			return nil
+		}
+		b, err = genFragmentationNeededPacket(packet, mtu)
+	} else if util.IsIPv6(packet) && len(packet) >= 40 && mtu >= minIPv6MTU {
+		b, err = genPacketTooBigPacket(packet, mtu)
+	}
+	if err != nil {
+		log.Debugf("[pmtu] failed to generate icmp error: %v", err)
+		return nil
+	}
+	return b
+}
+
+func genPMTUProbePacket(src, dst net.IP, size int) ([]byte, error) {
+	buf := gopacket.NewSerializeBuffer()
+	ipLayer := layers.IPv4{
+		Version:  4,
+		SrcIP:    src,
+		DstIP:    dst,
+		Protocol: layers.IPProtocolICMPv4,
+		Flags:    layers.IPv4DontFragment,
+		TTL:      64,
+		IHL:      5,
+	}
+	icmpLayer := layers.ICMPv4{
+		TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoRequest, 0),
+		Id:       pmtuProbeID,
+		Seq:      uint16(size),
+	}
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	err := gopacket.SerializeLayers(buf, opts, &ipLayer, &icmpLayer, gopacket.Payload(make([]byte, size-20-8)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize icmp packet, err: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// genFragmentationNeededPacket icmp destination unreachable, next-hop mtu is in last 2 bytes of header
+func genFragmentationNeededPacket(packet []byte, mtu int) ([]byte, error) {
+	ihl := int(packet[0]&0x0f) * 4
+	if len(packet) < ihl+8 {
+		return nil, fmt.Errorf("invalid ipv4 packet")
+	}
+	buf := gopacket.NewSerializeBuffer()
+	ipLayer := layers.IPv4{
+		Version:  4,
+		SrcIP:    config.RouterIP,
+		DstIP:    packet[12:16],
+		Protocol: layers.IPProtocolICMPv4,
+		TTL:      64,
+		IHL:      5,
+	}
+	icmpLayer := layers.ICMPv4{
+		TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable, layers.ICMPv4CodeFragmentationNeeded),
+		Seq:      uint16(mtu),
+	}
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	err := gopacket.SerializeLayers(buf, opts, &ipLayer, &icmpLayer, gopacket.Payload(packet[:ihl+8]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize icmp packet, err: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// genPacketTooBigPacket icmpv6 packet too big, whole icmp error must not exceed minimum ipv6 mtu
+func genPacketTooBigPacket(packet []byte, mtu int) ([]byte, error) {
+	body := make([]byte, 4, minIPv6MTU-40-4)
+	binary.BigEndian.PutUint32(body, uint32(mtu))
+	if len(packet) > cap(body)-len(body) {
+		packet = packet[:cap(body)-len(body)]
+	}
+	body = append(body, packet...)
+	buf := gopacket.NewSerializeBuffer()
+	ipLayer := layers.IPv6{
+		Version:    6,
+		SrcIP:      config.RouterIP6,
+		DstIP:      packet[8:24],
+		NextHeader: layers.IPProtocolICMPv6,
+		HopLimit:   255,
+	}
+	icmpLayer := layers.ICMPv6{
+		TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+	}
+	if err := icmpLayer.SetNetworkLayerForChecksum(&ipLayer); err != nil {
+		return nil, err
+	}
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	err := gopacket.SerializeLayers(buf, opts, &ipLayer, &icmpLayer, gopacket.Payload(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize icmp6 packet, err: %v", err)
+	}
+	return buf.Bytes(), nil
+}
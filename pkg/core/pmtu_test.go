@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/google/gopacket/layers"
+)
+
+func TestPathMTU(t *testing.T) {
+	src, dst := net.ParseIP("223.254.0.100").To4(), net.ParseIP("10.244.0.12").To4()
+	probe, err := genPMTUProbePacket(src, dst, 1400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(probe) != 1400 {
+		t.Fatalf("expect probe length 1400, but got %d", len(probe))
+	}
+
+	p := newPathMTU(nil)
+	if p.tooBig(probe) != nil {
+		t.Fatalf("path mtu is unknown, packet should pass")
+	}
+	p.Set(1300)
+	reply := p.tooBig(probe)
+	if reply == nil {
+		t.Fatalf("expect fragmentation needed")
+	}
+	icmp := reply[20:]
+	if icmp[0] != layers.ICMPv4TypeDestinationUnreachable || icmp[1] != layers.ICMPv4CodeFragmentationNeeded {
+		t.Fatalf("expect fragmentation needed, but got type %d code %d", icmp[0], icmp[1])
+	}
+	if mtu := binary.BigEndian.Uint16(icmp[6:8]); mtu != 1300 {
+		t.Fatalf("expect next-hop mtu 1300, but got %d", mtu)
+	}
+
+	// router reply fragmentation needed of probe
+	if !p.handleReply(reply) {
+		t.Fatalf("expect reply of probe")
+	}
+	if r := <-p.replies; r.ok || r.size != 1400 || r.nextHop != 1300 {
+		t.Fatalf("unexpected reply: %+v", r)
+	}
+}
+
+func TestPathMTUPerTunnel(t *testing.T) {
+	ip1, ip2 := net.ParseIP("223.254.0.100"), net.ParseIP("223.254.0.101")
+	p1, p2 := newPathMTU(nil), newPathMTU(nil)
+	PathMTUs.add(ip1, p1)
+	PathMTUs.add(ip2, p2)
+	defer PathMTUs.remove(ip1, p1)
+	defer PathMTUs.remove(ip2, p2)
+
+	PathMTUs.Set(ip1, 1300)
+	if p1.Get() != 1300 || p2.Get() != 0 {
+		t.Fatalf("expect path mtu of tunnels are independent, but got %d and %d", p1.Get(), p2.Get())
+	}
+	// tunnel reconnected, old one exits after new one is added
+	p3 := newPathMTU(nil)
+	PathMTUs.add(ip2, p3)
+	PathMTUs.remove(ip2, p2)
+	if PathMTUs.get(ip2) != p3 {
+		t.Fatalf("expect new tunnel is kept")
+	}
+	if _, err := PathMTUs.Probe(context.Background(), net.ParseIP("223.254.0.102"), ip1, 1500); err == nil {
+		t.Fatalf("expect tunnel is not ready")
+	}
+}
@@ -0,0 +1,228 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/util/cert"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+const (
+	// QUICNextProto alpn of protocol quic
+	QUICNextProto = "kubevpn"
+	// QUICProbeTimeout timeout of probing traffic manager quic port is reachable or not
+	QUICProbeTimeout = 3 * time.Second
+)
+
+var quicConfig = &quic.Config{
+	HandshakeIdleTimeout: config.DialTimeout,
+	MaxIdleTimeout:       60 * time.Second,
+	KeepAlivePeriod:      15 * time.Second,
+}
+
+type quicTransporter struct {
+	// pin sha256 of certificate of traffic manager in hex, client read certificate from secret of traffic manager
+	pin string
+}
+
+// QUICTransporter dial traffic manager over udp directly, every dial is a new quic connection with one stream,
+// packets are framed same as protocol tcp, so it can be used with UDPOverTCPTunnelConnector. quic connection is only
+// established if traffic manager presents certificate which sha256 is pin, eg: quic://192.168.1.100:30803?pin=<sha256>
+func QUICTransporter(pin string) Transporter {
+	return &quicTransporter{pin: pin}
+}
+
+func (tr *quicTransporter) Dial(ctx context.Context, addr string) (net.Conn, error) {
+	if len(tr.pin) == 0 {
+		return nil, fmt.Errorf("certificate of traffic manager is unknown")
+	}
+	// traffic manager use self-signed certificate, and it is not issued for address which client dials,
+	// like LoadBalancer ingress or NodePort, so pin the certificate instead of verifying host name
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyPinnedCertificate(tr.pin),
+		NextProtos:            []string{QUICNextProto},
+	}
+	conn, err := quic.DialAddr(ctx, addr, tlsConfig, quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return nil, err
+	}
+	return &quicConn{Stream: stream, conn: conn, closeConn: true}, nil
+}
+
+// QUICCertificatePin pin of first certificate of pem, it is sha256 of certificate in hex
+func QUICCertificatePin(pemCerts []byte) (string, error) {
+	for block, rest := pem.Decode(pemCerts); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			sum := sha256.Sum256(block.Bytes)
+			return hex.EncodeToString(sum[:]), nil
+		}
+	}
+	return "", fmt.Errorf("no certificate found")
+}
+
+// verifyPinnedCertificate sha256 of leaf certificate of peer must be pin
+func verifyPinnedCertificate(pin string) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(rawCerts) != 0 {
+			sum := sha256.Sum256(rawCerts[0])
+			if hex.EncodeToString(sum[:]) == strings.ToLower(pin) {
+				return nil
+			}
+		}
+		return fmt.Errorf("certificate of traffic manager not match")
+	}
+}
+
+// ProbeQUIC check quic port of traffic manager is reachable or not
+func ProbeQUIC(ctx context.Context, addr string, pin string) error {
+	ctx, cancel := context.WithTimeout(ctx, QUICProbeTimeout)
+	defer cancel()
+	conn, err := QUICTransporter(pin).Dial(ctx, addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+type quicListener struct {
+	ln     *quic.Listener
+	connCh chan net.Conn
+	errCh  chan error
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// QUICListener accept quic connections, every stream of connection is treated as one tcp connection
+func QUICListener(addr string) (net.Listener, error) {
+	tlsConfig, err := quicServerTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	ln, err := quic.ListenAddr(addr, tlsConfig, quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &quicListener{
+		ln:     ln,
+		connCh: make(chan net.Conn, 1024),
+		errCh:  make(chan error, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	go l.listen()
+	return l, nil
+}
+
+func (l *quicListener) listen() {
+	for {
+		conn, err := l.ln.Accept(l.ctx)
+		if err != nil {
+			l.errCh <- err
+			return
+		}
+		go l.acceptStream(conn)
+	}
+}
+
+func (l *quicListener) acceptStream(conn quic.Connection) {
+	for {
+		stream, err := conn.AcceptStream(l.ctx)
+		if err != nil {
+			log.Debugf("[quic] accept stream from %s error: %v", conn.RemoteAddr(), err)
+			return
+		}
+		select {
+		case l.connCh <- &quicConn{Stream: stream, conn: conn}:
+		case <-l.ctx.Done():
+			_ = conn.CloseWithError(0, "")
+			return
+		}
+	}
+}
+
+func (l *quicListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.connCh:
+		return conn, nil
+	case err := <-l.errCh:
+		return nil, err
+	}
+}
+
+func (l *quicListener) Close() error {
+	l.cancel()
+	return l.ln.Close()
+}
+
+func (l *quicListener) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+type quicConn struct {
+	quic.Stream
+	conn quic.Connection
+	// client side close whole connection, server side only close stream
+	closeConn bool
+	once      sync.Once
+}
+
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *quicConn) Close() error {
+	var err error
+	c.once.Do(func() {
+		c.Stream.CancelRead(0)
+		err = c.Stream.Close()
+		if c.closeConn {
+			err = c.conn.CloseWithError(0, "")
+		}
+	})
+	return err
+}
+
+// quicServerTLSConfig use certificate of traffic manager secret, otherwise generate self-signed one
+func quicServerTLSConfig() (*tls.Config, error) {
+	crt, key := []byte(os.Getenv(config.TLSCertKey)), []byte(os.Getenv(config.TLSPrivateKeyKey))
+	if len(crt) == 0 || len(key) == 0 {
+		var err error
+		crt, key, err = cert.GenerateSelfSignedCertKey(config.ConfigMapPodTrafficManager, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate self-signed certificate, err: %v", err)
+		}
+	}
+	pair, err := tls.X509KeyPair(crt, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate and key, err: %v", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		NextProtos:   []string{QUICNextProto},
+	}, nil
+}
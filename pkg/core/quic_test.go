@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/cert"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+func TestQUIC(t *testing.T) {
+	crt, key, err := cert.GenerateSelfSignedCertKey(config.ConfigMapPodTrafficManager, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.TLSCertKey, string(crt))
+	t.Setenv(config.TLSPrivateKeyKey, string(key))
+	pin, err := QUICCertificatePin(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := QUICListener("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	conn, err := QUICTransporter(pin).Dial(ctx, ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte("kubevpn")); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 7)
+	if _, err = io.ReadFull(conn, b); err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "kubevpn" {
+		t.Fatalf("expect kubevpn, but got %s", b)
+	}
+	if err = ProbeQUIC(ctx, "127.0.0.1:1", pin); err == nil {
+		t.Fatalf("expect probe unreachable address failed")
+	}
+	// certificate of traffic manager is not the pinned one
+	other, _, _ := cert.GenerateSelfSignedCertKey(config.ConfigMapPodTrafficManager, nil, nil)
+	otherPin, _ := QUICCertificatePin(other)
+	if err = ProbeQUIC(ctx, ln.Addr().String(), otherPin); err == nil {
+		t.Fatalf("expect dial traffic manager with other certificate failed")
+	}
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+const (
+	// minBurst burst must be larger than max size of packet
+	minBurst = 1 << 16
+	// clientQueueSize packets to one client which exceed it are dropped
+	clientQueueSize = 256
+	// clientQueueIdle queue of client is removed after idle for a while
+	clientQueueIdle = time.Minute
+)
+
+// RateLimitConfig per client bandwidth limits of traffic manager, saved in configmap, eg:
+//
+//	{"default": {"rate": "10Mi"}, "clients": {"223.254.0.101": {"rate": "1Mi"}, "223.254.1.0/24": {"rate": "5Mi"}}}
+type RateLimitConfig struct {
+	// Default limit of every client
+	Default RateLimit `json:"default,omitempty"`
+	// Clients limit of client which overrides default, key is tun ipv4 or cidr of client
+	Clients map[string]RateLimit `json:"clients,omitempty"`
+}
+
+// RateLimit bytes per second of one client, upload and download are limited separately, zero means unlimited
+type RateLimit struct {
+	Rate  resource.Quantity `json:"rate,omitempty"`
+	Burst resource.Quantity `json:"burst,omitempty"`
+}
+
+func (l RateLimit) limit() (rate.Limit, int) {
+	r := l.Rate.Value()
+	if r <= 0 {
+		return rate.Inf, 0
+	}
+	burst := l.Burst.Value()
+	if burst <= 0 {
+		burst = r
+	}
+	if burst < minBurst {
+		burst = minBurst
+	}
+	return rate.Limit(r), int(burst)
+}
+
+// RateLimits limiters of clients, client is identified by tun ip, ipv6 of client shares limiter of its ipv4
+var RateLimits = &rateLimitStore{limiters: map[string]*clientLimiter{}}
+
+type rateLimitStore struct {
+	lock   sync.RWMutex
+	config *RateLimitConfig
+	// map[tun ip]*clientLimiter
+	limiters map[string]*clientLimiter
+}
+
+type clientLimiter struct {
+	// tx is sent to client, rx is received from client
+	tx *rate.Limiter
+	rx *rate.Limiter
+}
+
+// Update replace limits, limiters of connected clients are updated in place
+func (s *rateLimitStore) Update(c *RateLimitConfig) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.config = c
+	for ip, l := range s.limiters {
+		limit, burst := s.lookup(net.ParseIP(ip))
+		for _, limiter := range []*rate.Limiter{l.tx, l.rx} {
+			limiter.SetLimit(limit)
+			limiter.SetBurst(burst)
+		}
+	}
+}
+
+// lookup most specific limit of client
+func (s *rateLimitStore) lookup(ip net.IP) (rate.Limit, int) {
+	if s.config == nil {
+		return rate.Inf, 0
+	}
+	var match = s.config.Default
+	var ones = -1
+	for k, v := range s.config.Clients {
+		if !strings.Contains(k, "/") {
+			if ip.Equal(net.ParseIP(k)) {
+				return v.limit()
+			}
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(k)
+		if err != nil || !cidr.Contains(ip) {
+			continue
+		}
+		if size, _ := cidr.Mask.Size(); size > ones {
+			match, ones = v, size
+		}
+	}
+	return match.limit()
+}
+
+func (s *rateLimitStore) get(ip net.IP) *clientLimiter {
+	key := PeerStats.Key(ip)
+	s.lock.RLock()
+	l, ok := s.limiters[key]
+	enabled := s.config != nil
+	s.lock.RUnlock()
+	if ok || !enabled {
+		return l
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if l, ok = s.limiters[key]; !ok {
+		limit, burst := s.lookup(net.ParseIP(key))
+		l = &clientLimiter{tx: rate.NewLimiter(limit, burst), rx: rate.NewLimiter(limit, burst)}
+		s.limiters[key] = l
+	}
+	return l
+}
+
+// Remove client is gone
+func (s *rateLimitStore) Remove(ip net.IP) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.limiters, PeerStats.Key(ip))
+}
+
+// WaitRx wait until packet from client is allowed, for client over tcp, slow down reading makes client slow down
+func (s *rateLimitStore) WaitRx(ctx context.Context, ip net.IP, length int) error {
+	if l := s.get(ip); l != nil {
+		return l.rx.WaitN(ctx, length)
+	}
+	return nil
+}
+
+// AllowRx packet from client over udp is dropped if it exceeds limit
+func (s *rateLimitStore) AllowRx(ip net.IP, length int) bool {
+	if l := s.get(ip); l != nil {
+		return l.rx.AllowN(time.Now(), length)
+	}
+	return true
+}
+
+func (s *rateLimitStore) waitTx(ctx context.Context, ip net.IP, length int) error {
+	if l := s.get(ip); l != nil {
+		return l.tx.WaitN(ctx, length)
+	}
+	return nil
+}
+
+// queuedPacket packet to client over udp (addr) or tcp (conn)
+type queuedPacket struct {
+	*DataElem
+	addr net.Addr
+	conn net.Conn
+}
+
+// clientQueues every client has its own queue which is served by its own goroutine, so a slow or rate limited client
+// only delays its own packets instead of blocking all clients
+type clientQueues struct {
+	ctx   context.Context
+	write func(*queuedPacket) error
+	lock  sync.Mutex
+	// map[tun ip]chan *queuedPacket
+	queues map[string]chan *queuedPacket
+}
+
+func newClientQueues(ctx context.Context, write func(*queuedPacket) error) *clientQueues {
+	return &clientQueues{ctx: ctx, write: write, queues: map[string]chan *queuedPacket{}}
+}
+
+// enqueue packet to client, packet is dropped if queue of client is full
+func (q *clientQueues) enqueue(packet *queuedPacket) {
+	key := PeerStats.Key(packet.dst)
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	ch, ok := q.queues[key]
+	if !ok {
+		ch = make(chan *queuedPacket, clientQueueSize)
+		q.queues[key] = ch
+		go q.serve(key, ch)
+	}
+	select {
+	case ch <- packet:
+	default:
+		PeerStats.Drop(packet.dst)
+		config.LPool.Put(packet.data[:])
+	}
+}
+
+func (q *clientQueues) serve(key string, ch chan *queuedPacket) {
+	ticker := time.NewTicker(clientQueueIdle)
+	defer ticker.Stop()
+	var idle bool
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case packet := <-ch:
+			idle = false
+			if err := RateLimits.waitTx(q.ctx, packet.dst, packet.length); err != nil {
+				config.LPool.Put(packet.data[:])
+				continue
+			}
+			err := q.write(packet)
+			config.LPool.Put(packet.data[:])
+			if err != nil {
+				log.Debugf("[tun] failed to write packet to %s: %v", packet.dst, err)
+				PeerStats.Drop(packet.dst)
+				continue
+			}
+			PeerStats.Tx(packet.dst, packet.length)
+		case <-ticker.C:
+			if !idle {
+				idle = true
+				continue
+			}
+			q.lock.Lock()
+			if len(ch) == 0 {
+				delete(q.queues, key)
+				q.lock.Unlock()
+				return
+			}
+			q.lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+func TestRateLimit(t *testing.T) {
+	var c RateLimitConfig
+	err := json.Unmarshal([]byte(`{"default": {"rate": "10Mi"}, "clients": {"223.254.0.101": {"rate": "1Mi"}, "223.254.1.0/24": {"rate": "5Mi", "burst": "1Mi"}}}`), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &rateLimitStore{limiters: map[string]*clientLimiter{}}
+	if l := s.get(net.ParseIP("223.254.0.100")); l != nil {
+		t.Fatalf("expect no limiter if rate limit is not configured")
+	}
+	s.Update(&c)
+	for ip, expect := range map[string]rate.Limit{
+		"223.254.0.100": 10 << 20,
+		"223.254.0.101": 1 << 20,
+		"223.254.1.2":   5 << 20,
+	} {
+		if limit, _ := s.lookup(net.ParseIP(ip)); limit != expect {
+			t.Fatalf("expect limit of %s is %v, but got %v", ip, expect, limit)
+		}
+	}
+	if _, burst := s.lookup(net.ParseIP("223.254.1.2")); burst != 1<<20 {
+		t.Fatalf("expect burst 1Mi, but got %d", burst)
+	}
+}
+
+func TestClientQueues(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slow, fast := net.ParseIP("223.254.0.101"), net.ParseIP("223.254.0.102")
+	block := make(chan struct{})
+	written := make(chan string, 10)
+	q := newClientQueues(ctx, func(packet *queuedPacket) error {
+		if packet.dst.Equal(slow) {
+			<-block
+		}
+		written <- packet.dst.String()
+		return nil
+	})
+	defer close(block)
+	for _, ip := range []net.IP{slow, slow, fast} {
+		data := config.LPool.Get().([]byte)[:]
+		q.enqueue(&queuedPacket{DataElem: NewDataElem(data, 20, nil, ip)})
+	}
+	select {
+	case ip := <-written:
+		if ip != fast.String() {
+			t.Fatalf("expect packet to %s, but got %s", fast, ip)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("packet to %s is blocked by slow client", fast)
+	}
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/tun"
+)
+
+// NewHandlerFunc create handler of serve node (-L), chain is forward chain (-F), it is empty if not specified
+type NewHandlerFunc func(chain *Chain, node *Node) Handler
+
+// NewListenerFunc create listener of serve node (-L)
+type NewListenerFunc func(node *Node) (net.Listener, error)
+
+// NewTransporterFunc create transporter which dial address of chain node (-F)
+type NewTransporterFunc func(node *Node) Transporter
+
+// NewConnectorFunc wrap connector of chain node (-F), connector frames packets over conn, and seals them if needed
+type NewConnectorFunc func(node *Node, connector Connector) Connector
+
+// protocols registered handlers, listeners, transporters and connectors keyed by scheme of node, third-party protocols
+// are registered before Route.GenerateServers, eg: RegisterListener("kcp", ...)
+var protocols = &protocolRegistry{
+	handlers:     map[string]NewHandlerFunc{},
+	listeners:    map[string]NewListenerFunc{},
+	transporters: map[string]NewTransporterFunc{},
+	connectors:   map[string]NewConnectorFunc{},
+}
+
+type protocolRegistry struct {
+	lock         sync.RWMutex
+	handlers     map[string]NewHandlerFunc
+	listeners    map[string]NewListenerFunc
+	transporters map[string]NewTransporterFunc
+	connectors   map[string]NewConnectorFunc
+}
+
+// RegisterHandler register handler of scheme, it replaces handler which is registered before
+func RegisterHandler(scheme string, f NewHandlerFunc) {
+	protocols.lock.Lock()
+	defer protocols.lock.Unlock()
+	protocols.handlers[scheme] = f
+}
+
+// RegisterListener register listener of scheme, serve node of scheme needs both handler and listener
+func RegisterListener(scheme string, f NewListenerFunc) {
+	protocols.lock.Lock()
+	defer protocols.lock.Unlock()
+	protocols.listeners[scheme] = f
+}
+
+// RegisterTransporter register transporter of scheme, chain node of scheme needs transporter
+func RegisterTransporter(scheme string, f NewTransporterFunc) {
+	protocols.lock.Lock()
+	defer protocols.lock.Unlock()
+	protocols.transporters[scheme] = f
+}
+
+// RegisterConnector register connector of scheme, it is optional, default connector is used if not registered
+func RegisterConnector(scheme string, f NewConnectorFunc) {
+	protocols.lock.Lock()
+	defer protocols.lock.Unlock()
+	protocols.connectors[scheme] = f
+}
+
+// newServer create handler and listener of serve node
+func (r *protocolRegistry) newServer(chain *Chain, node *Node) (*Server, error) {
+	r.lock.RLock()
+	newHandler, ok1 := r.handlers[node.Protocol]
+	newListener, ok2 := r.listeners[node.Protocol]
+	r.lock.RUnlock()
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("not support protocol %s", node.Protocol)
+	}
+	ln, err := newListener(node)
+	if err != nil {
+		return nil, fmt.Errorf("create %s listener error: %v", node.Protocol, err)
+	}
+	return &Server{Listener: ln, Handler: newHandler(chain, node)}, nil
+}
+
+// newClient create transporter and connector of chain node
+func (r *protocolRegistry) newClient(node *Node, connector Connector) (*Client, error) {
+	transporter, err := r.newTransporter(node)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	newConnector := r.connectors[node.Protocol]
+	r.lock.RUnlock()
+	if newConnector != nil {
+		connector = newConnector(node, connector)
+	}
+	return &Client{Connector: connector, Transporter: transporter}, nil
+}
+
+func (r *protocolRegistry) newTransporter(node *Node) (Transporter, error) {
+	r.lock.RLock()
+	newTransporter, ok := r.transporters[node.Protocol]
+	r.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("not support protocol %s", node.Protocol)
+	}
+	return newTransporter(node), nil
+}
+
+func init() {
+	RegisterHandler("tun", func(chain *Chain, node *Node) Handler {
+		return TunHandler(chain, node)
+	})
+	RegisterListener("tun", func(node *Node) (net.Listener, error) {
+		return tun.Listener(tun.Config{
+			Name:    node.Get("name"),
+			Addr:    node.Get("net"),
+			Addr6:   os.Getenv(config.EnvInboundPodTunIPv6),
+			MTU:     node.GetInt("mtu"),
+			Routes:  parseIPRoutes(node.Get("route")),
+			Gateway: node.Get("gw"),
+		})
+	})
+
+	for _, scheme := range []string{"tcp", "quic"} {
+		RegisterHandler(scheme, func(*Chain, *Node) Handler {
+			return TCPHandler()
+		})
+	}
+	RegisterListener("tcp", func(node *Node) (net.Listener, error) {
+		return TCPListener(node.Addr)
+	})
+	RegisterListener("quic", func(node *Node) (net.Listener, error) {
+		return QUICListener(node.Addr)
+	})
+
+	RegisterHandler("peer", func(*Chain, *Node) Handler {
+		return PeerHandler()
+	})
+	RegisterListener("peer", func(node *Node) (net.Listener, error) {
+		return TCPListener(node.Addr)
+	})
+
+	RegisterHandler("gtcp", func(*Chain, *Node) Handler {
+		return GvisorTCPHandler()
+	})
+	RegisterListener("gtcp", func(node *Node) (net.Listener, error) {
+		return GvisorTCPListener(node.Addr)
+	})
+	RegisterHandler("gudp", func(*Chain, *Node) Handler {
+		return GvisorUDPHandler()
+	})
+	RegisterListener("gudp", func(node *Node) (net.Listener, error) {
+		return GvisorUDPListener(node.Addr)
+	})
+
+	for _, scheme := range []string{"tcp", "mtcp"} {
+		RegisterTransporter(scheme, func(*Node) Transporter {
+			return TCPTransporter()
+		})
+	}
+	RegisterConnector("mtcp", func(node *Node, connector Connector) Connector {
+		return MultiStreamConnector(node.GetInt("streams"), connector)
+	})
+	RegisterTransporter("quic", func(node *Node) Transporter {
+		return QUICTransporter(node.Get("pin"))
+	})
+
+	RegisterHandler("ws", func(*Chain, *Node) Handler {
+		return WebSocketHandler()
+	})
+	RegisterListener("ws", func(node *Node) (net.Listener, error) {
+		return WebSocketListener(node.Addr)
+	})
+	for _, scheme := range []string{"ws", "wss"} {
+		RegisterTransporter(scheme, WebSocketTransporter)
+	}
+	RegisterTransporter("connect", HTTPConnectTransporter)
+}
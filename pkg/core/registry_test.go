@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+type testTransporter struct {
+	addr string
+}
+
+func (t *testTransporter) Dial(ctx context.Context, addr string) (net.Conn, error) {
+	t.addr = addr
+	return nil, net.ErrClosed
+}
+
+func TestRegisterProtocol(t *testing.T) {
+	transporter := &testTransporter{}
+	RegisterHandler("test", func(*Chain, *Node) Handler {
+		return TCPHandler()
+	})
+	RegisterListener("test", func(node *Node) (net.Listener, error) {
+		return TCPListener(node.Addr)
+	})
+	RegisterTransporter("test", func(*Node) Transporter {
+		return transporter
+	})
+
+	r := &Route{ServeNodes: []string{"test://127.0.0.1:0"}, ChainNode: "test://127.0.0.1:10800"}
+	servers, err := r.GenerateServers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer servers[0].Listener.Close()
+	if _, ok := servers[0].Handler.(*fakeUdpHandler); !ok {
+		t.Fatalf("expect handler of registered protocol, but got %T", servers[0].Handler)
+	}
+
+	chain, err := r.parseChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = chain.DialContext(context.Background())
+	if transporter.addr != "127.0.0.1:10800" {
+		t.Fatalf("expect dial by registered transporter, but got %q", transporter.addr)
+	}
+
+	r = &Route{ServeNodes: []string{"unknown://:0"}}
+	if _, err = r.GenerateServers(); err == nil {
+		t.Fatalf("expect error of unknown protocol")
+	}
+}
@@ -1,18 +1,13 @@
 package core
 
 import (
-	"fmt"
 	"net"
-	"os"
 	"strings"
 	"sync"
 
 	"github.com/containernetworking/cni/pkg/types"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-
-	"github.com/wencaiwulue/kubevpn/pkg/config"
-	"github.com/wencaiwulue/kubevpn/pkg/tun"
 )
 
 var (
@@ -32,6 +27,11 @@ type TCPUDPacket struct {
 // -L "tcp://:10800" -L "tun://:8422?net=223.254.0.100/16"
 // -L "tun:/10.233.24.133:8422?net=223.254.0.102/16&route=223.254.0.0/16"
 // -L "tun:/127.0.0.1:8422?net=223.254.0.102/16&route=223.254.0.0/16,10.233.0.0/16" -F "tcp://127.0.0.1:10800"
+// -L "tun:/127.0.0.1:8422?net=223.254.0.102/16&route=223.254.0.0/16,10.233.0.0/16" -F "mtcp://127.0.0.1:10800?streams=4"
+// -L "tun:/127.0.0.1:8422?net=223.254.0.102/16&route=223.254.0.0/16,10.233.0.0/16" -F "quic://192.168.1.100:30803?pin=<sha256 of certificate>"
+// -L "tun:/127.0.0.1:8422?net=223.254.0.102/16&route=223.254.0.0/16,10.233.0.0/16" -F "tcp://127.0.0.1:10800?key=<tunnel key token>"
+// -L "tun:/127.0.0.1:8422?net=223.254.0.102/16&route=223.254.0.0/16,10.233.0.0/16" -F "tcp://127.0.0.1:10800?session=true"
+// -L "tun:/127.0.0.1:8422?net=223.254.0.102/16&route=223.254.0.0/16&gtcp=<gvisor tcp forward addr>&gudp=<gvisor udp forward addr>" -F "tcp://127.0.0.1:10800"
 type Route struct {
 	ServeNodes []string // -L tun
 	ChainNode  string   // -F tcp
@@ -53,9 +53,27 @@ func parseChainNode(ns string) (*Node, error) {
 		log.Errorf("parse node error: %v", err)
 		return nil, err
 	}
-	node.Client = &Client{
-		Connector:   UDPOverTCPTunnelConnector(),
-		Transporter: TCPTransporter(),
+	var connector = UDPOverTCPTunnelConnector()
+	// resume session after reconnect, streams of mtcp are reconnected together, so it is not supported
+	if node.Get("session") == "true" && node.Protocol != "mtcp" {
+		connector, err = SessionConnector(connector)
+		if err != nil {
+			log.Errorf("create session connector error: %v", err)
+			return nil, err
+		}
+	}
+	// seal packets with tunnel key if traffic manager issued one
+	if token := node.Get("key"); token != "" {
+		connector, err = TunnelKeyConnector(connector, token)
+		if err != nil {
+			log.Errorf("parse tunnel key error: %v", err)
+			return nil, err
+		}
+	}
+	node.Client, err = protocols.newClient(node, connector)
+	if err != nil {
+		log.Errorf("create client of %s error: %v", node.Protocol, err)
+		return nil, err
 	}
 	return node, nil
 }
@@ -76,50 +94,13 @@ func (r *Route) GenerateServers() ([]Server, error) {
 			return nil, err
 		}
 
-		var ln net.Listener
-		var handler Handler
-
-		switch node.Protocol {
-		case "tun":
-			handler = TunHandler(chain, node)
-			ln, err = tun.Listener(tun.Config{
-				Name:    node.Get("name"),
-				Addr:    node.Get("net"),
-				Addr6:   os.Getenv(config.EnvInboundPodTunIPv6),
-				MTU:     node.GetInt("mtu"),
-				Routes:  parseIPRoutes(node.Get("route")),
-				Gateway: node.Get("gw"),
-			})
-			if err != nil {
-				log.Errorf("create tun listener error: %v", err)
-				return nil, err
-			}
-		case "tcp":
-			handler = TCPHandler()
-			ln, err = TCPListener(node.Addr)
-			if err != nil {
-				log.Errorf("create tcp listener error: %v", err)
-				return nil, err
-			}
-		case "gtcp":
-			handler = GvisorTCPHandler()
-			ln, err = GvisorTCPListener(node.Addr)
-			if err != nil {
-				log.Errorf("create gvisor tcp listener error: %v", err)
-				return nil, err
-			}
-		case "gudp":
-			handler = GvisorUDPHandler()
-			ln, err = GvisorUDPListener(node.Addr)
-			if err != nil {
-				log.Errorf("create gvisor udp listener error: %v", err)
-				return nil, err
-			}
-		default:
-			log.Errorf("not support protocol %s", node.Protocol)
-			return nil, fmt.Errorf("not support protocol %s", node.Protocol)
+		var server *Server
+		server, err = protocols.newServer(chain, node)
+		if err != nil {
+			log.Error(err)
+			return nil, err
 		}
-		servers = append(servers, Server{Listener: ln, Handler: handler})
+		servers = append(servers, *server)
 	}
 	return servers, nil
 }
@@ -0,0 +1,403 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+const (
+	// sessionHello first frame of resumable conn, it is never a valid ip packet or sealed packet
+	sessionHello  = 0xff
+	sessionIDSize = 16
+	// hello: 0xff, session id, count of frames client received
+	sessionHelloSize = 1 + sessionIDSize + 8
+	// reply: 0xff, resumed or not, count of frames server received
+	sessionReplySize = 1 + 1 + 8
+	// sessionReplayBytes recent frames are kept for replay, older frames are lost if reconnect takes too long
+	sessionReplayBytes = 1 << 21
+	// sessionTimeout server closes session if client does not reconnect in time
+	sessionTimeout          = time.Minute * 2
+	sessionHandshakeTimeout = time.Second * 5
+)
+
+// replayBuffer recent frames, sequence of frame is index of it since session started
+type replayBuffer struct {
+	frames [][]byte
+	size   int
+	// next sequence, it is also count of frames
+	next uint64
+}
+
+func (r *replayBuffer) add(frame []byte) {
+	r.frames = append(r.frames, append([]byte(nil), frame...))
+	r.size += len(frame)
+	r.next++
+	for r.size > sessionReplayBytes && len(r.frames) > 1 {
+		r.size -= len(r.frames[0])
+		r.frames[0] = nil
+		r.frames = r.frames[1:]
+	}
+}
+
+// since frames which peer has not received, peer has received count frames
+func (r *replayBuffer) since(count uint64) [][]byte {
+	first := r.next - uint64(len(r.frames))
+	if count >= r.next {
+		return nil
+	}
+	if count < first {
+		log.Debugf("[session] %d frames are lost, they are dropped from replay buffer", first-count)
+		count = first
+	}
+	return r.frames[count-first:]
+}
+
+func (r *replayBuffer) reset() {
+	*r = replayBuffer{}
+}
+
+func isSessionHello(b []byte) bool {
+	return len(b) == sessionHelloSize && b[0] == sessionHello
+}
+
+type sessionConnector struct {
+	connector Connector
+	id        []byte
+	// lock protect replay and current, writes are serialized by it
+	lock    sync.Mutex
+	replay  replayBuffer
+	current *clientSessionConn
+	rx      atomic.Uint64
+}
+
+// SessionConnector resume session after reconnect, both sides replay frames which peer has not received, so port-forward
+// drop looks like a short delay instead of packet loss to connections over tunnel
+func SessionConnector(connector Connector) (Connector, error) {
+	id := make([]byte, sessionIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &sessionConnector{connector: connector, id: id}, nil
+}
+
+func (c *sessionConnector) ConnectContext(ctx context.Context, conn net.Conn) (net.Conn, error) {
+	cc, err := c.connector.ConnectContext(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := c.handshake(cc)
+	if err != nil {
+		_ = cc.Close()
+		return nil, err
+	}
+	return sc, nil
+}
+
+func (c *sessionConnector) handshake(cc net.Conn) (*clientSessionConn, error) {
+	packetConn, ok := cc.(net.PacketConn)
+	if !ok {
+		return nil, fmt.Errorf("not a packet connection")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	hello := make([]byte, sessionHelloSize)
+	hello[0] = sessionHello
+	copy(hello[1:], c.id)
+	binary.BigEndian.PutUint64(hello[1+sessionIDSize:], c.rx.Load())
+	if _, err := packetConn.WriteTo(hello, nil); err != nil {
+		return nil, err
+	}
+	b := config.LPool.Get().([]byte)[:]
+	defer config.LPool.Put(b[:])
+	_ = cc.SetReadDeadline(time.Now().Add(sessionHandshakeTimeout))
+	n, _, err := packetConn.ReadFrom(b[:])
+	_ = cc.SetReadDeadline(time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("session handshake failed: %v", err)
+	}
+	if n != sessionReplySize || b[0] != sessionHello {
+		return nil, fmt.Errorf("invalid session handshake reply")
+	}
+	if b[1] == 0 {
+		if c.replay.next != 0 {
+			log.Debugf("[session] session %x is not found on server, start new session", c.id)
+		}
+		c.replay.reset()
+		c.rx.Store(0)
+	} else {
+		frames := c.replay.since(binary.BigEndian.Uint64(b[2:sessionReplySize]))
+		for _, frame := range frames {
+			if _, err = packetConn.WriteTo(frame, nil); err != nil {
+				return nil, err
+			}
+		}
+		log.Debugf("[session] resume session %x, replay %d packets", c.id, len(frames))
+	}
+	c.current = &clientSessionConn{Conn: cc, session: c}
+	return c.current, nil
+}
+
+// clientSessionConn one conn of session, frames written to it are kept for replay
+type clientSessionConn struct {
+	net.Conn
+	session *sessionConnector
+}
+
+func (c *clientSessionConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, addr, err := c.Conn.(net.PacketConn).ReadFrom(b)
+	if err == nil {
+		c.session.rx.Add(1)
+	}
+	return n, addr, err
+}
+
+func (c *clientSessionConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	c.session.lock.Lock()
+	defer c.session.lock.Unlock()
+	// frame must not be counted if session already moved to new conn
+	if c.session.current != c {
+		return 0, net.ErrClosed
+	}
+	c.session.replay.add(b)
+	return c.Conn.(net.PacketConn).WriteTo(b, addr)
+}
+
+// Sessions resumable sessions of clients in traffic manager
+var Sessions = &sessionRegistry{sessions: map[string]*serverSession{}}
+
+type sessionRegistry struct {
+	lock sync.Mutex
+	// map[session id]*serverSession
+	sessions map[string]*serverSession
+	once     sync.Once
+}
+
+// attach tcp conn to session of hello, init is called once session is created
+func (r *sessionRegistry) attach(hello []byte, conn net.Conn, init func(s *serverSession)) (*serverSession, error) {
+	if !isSessionHello(hello) {
+		return nil, fmt.Errorf("invalid session hello")
+	}
+	r.once.Do(func() {
+		go r.expire()
+	})
+	id := hex.EncodeToString(hello[1 : 1+sessionIDSize])
+	r.lock.Lock()
+	s, resumed := r.sessions[id]
+	if !resumed {
+		s = &serverSession{id: id}
+		init(s)
+		r.sessions[id] = s
+	}
+	r.lock.Unlock()
+	if err := s.attach(conn, resumed, binary.BigEndian.Uint64(hello[1+sessionIDSize:])); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// remove session, client starts a new session if it reconnects
+func (r *sessionRegistry) remove(s *serverSession) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.sessions[s.id] == s {
+		delete(r.sessions, s.id)
+	}
+}
+
+// expire close sessions which client does not reconnect in time
+func (r *sessionRegistry) expire() {
+	ticker := time.NewTicker(sessionTimeout / 4)
+	defer ticker.Stop()
+	for range ticker.C {
+		var expired []*serverSession
+		r.lock.Lock()
+		for id, s := range r.sessions {
+			if s.isExpired(time.Now()) {
+				delete(r.sessions, id)
+				expired = append(expired, s)
+			}
+		}
+		r.lock.Unlock()
+		for _, s := range expired {
+			log.Debugf("[session] session %s is expired", s.id)
+			_ = s.Close()
+		}
+	}
+}
+
+// serverSession server side of session, it is stable conn of client in route table, frames written to it are kept for
+// replay and are sent to current tcp conn of client, or buffered while client is reconnecting
+type serverSession struct {
+	id string
+	// lock serialize writes and replay
+	lock   sync.Mutex
+	replay replayBuffer
+	rx     atomic.Uint64
+	closed atomic.Bool
+	once   sync.Once
+
+	connLock   sync.Mutex
+	conn       net.Conn
+	localAddr  net.Addr
+	remoteAddr net.Addr
+	detachedAt time.Time
+
+	// route conn of client in route table, it is session or session wrapped by authConn
+	route   net.Conn
+	onClose func()
+}
+
+func (s *serverSession) attach(conn net.Conn, resumed bool, rx uint64) error {
+	// close old conn first, write to half-open conn may block
+	s.connLock.Lock()
+	old := s.conn
+	s.conn = nil
+	s.connLock.Unlock()
+	if old != nil {
+		_ = old.Close()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed.Load() {
+		return fmt.Errorf("session %s is closed", s.id)
+	}
+	reply := make([]byte, sessionReplySize)
+	reply[0] = sessionHello
+	var frames [][]byte
+	if resumed {
+		reply[1] = 1
+		frames = s.replay.since(rx)
+	}
+	binary.BigEndian.PutUint64(reply[2:], s.rx.Load())
+	if err := newDatagramPacket(reply).Write(conn); err != nil {
+		return err
+	}
+	for _, frame := range frames {
+		if _, err := conn.Write(frame); err != nil {
+			return err
+		}
+	}
+	s.connLock.Lock()
+	s.conn, s.localAddr, s.remoteAddr = conn, conn.LocalAddr(), conn.RemoteAddr()
+	s.connLock.Unlock()
+	if resumed {
+		log.Debugf("[session] resume session %s from %s, replay %d packets", s.id, conn.RemoteAddr(), len(frames))
+	}
+	return nil
+}
+
+// detach tcp conn of client is gone, wait for client to reconnect
+func (s *serverSession) detach(conn net.Conn) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	if s.conn == conn {
+		s.conn = nil
+		s.detachedAt = time.Now()
+	}
+}
+
+func (s *serverSession) isDetached() bool {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	return s.conn == nil
+}
+
+func (s *serverSession) isExpired(now time.Time) bool {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	return s.conn == nil && now.Sub(s.detachedAt) > sessionTimeout
+}
+
+// received count frames received from client
+func (s *serverSession) received() {
+	s.rx.Add(1)
+}
+
+// sessionOf session of route conn in route table, nil if client does not resume session
+func sessionOf(conn net.Conn) *serverSession {
+	switch c := conn.(type) {
+	case *serverSession:
+		return c
+	case *authConn:
+		s, _ := c.Conn.(*serverSession)
+		return s
+	}
+	return nil
+}
+
+func (s *serverSession) Write(b []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed.Load() {
+		return 0, net.ErrClosed
+	}
+	s.replay.add(b)
+	s.connLock.Lock()
+	conn := s.conn
+	s.connLock.Unlock()
+	if conn == nil {
+		return len(b), nil
+	}
+	if _, err := conn.Write(b); err != nil {
+		log.Debugf("[session] failed to write to %s, wait for client to reconnect: %v", conn.RemoteAddr(), err)
+		_ = conn.Close()
+		s.detach(conn)
+	}
+	return len(b), nil
+}
+
+func (s *serverSession) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("session %s is write only", s.id)
+}
+
+func (s *serverSession) Close() error {
+	s.once.Do(func() {
+		s.closed.Store(true)
+		s.connLock.Lock()
+		if s.conn != nil {
+			_ = s.conn.Close()
+		}
+		s.connLock.Unlock()
+		if s.onClose != nil {
+			s.onClose()
+		}
+	})
+	return nil
+}
+
+func (s *serverSession) LocalAddr() net.Addr {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	return s.localAddr
+}
+
+func (s *serverSession) RemoteAddr() net.Addr {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	return s.remoteAddr
+}
+
+func (s *serverSession) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (s *serverSession) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (s *serverSession) SetWriteDeadline(time.Time) error {
+	return nil
+}
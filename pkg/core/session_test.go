@@ -0,0 +1,110 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	h := &fakeUdpHandler{connNAT: &sync.Map{}, ch: make(chan *datagramPacket, 10)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go h.Handle(ctx, conn)
+		}
+	}()
+
+	connector, err := SessionConnector(UDPOverTCPTunnelConnector())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dial := func() net.PacketConn {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		cc, err := connector.ConnectContext(ctx, conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cc.(net.PacketConn)
+	}
+	packet := make([]byte, 20)
+	packet[0] = 0x45
+	copy(packet[12:16], net.ParseIP("223.254.0.100").To4())
+	copy(packet[16:20], net.ParseIP("223.254.0.1").To4())
+
+	first := dial()
+	if _, err = first.WriteTo(packet, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-h.ch:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("server not receive packet")
+	}
+	route, ok := h.connNAT.Load("223.254.0.100")
+	if !ok {
+		t.Fatalf("route of client not found")
+	}
+
+	// port-forward drops, packets to client are buffered until it reconnects
+	_ = first.Close()
+	reply := append([]byte(nil), packet...)
+	reply[19] = 2
+	if err = writeDatagramPacket(route.(net.Conn), reply); err != nil {
+		t.Fatal(err)
+	}
+
+	second := dial()
+	b := make([]byte, 1500)
+	_ = second.(net.Conn).SetReadDeadline(time.Now().Add(time.Second * 5))
+	n, _, err := second.ReadFrom(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b[:n], reply) {
+		t.Fatalf("expect replayed packet %v, but got %v", reply, b[:n])
+	}
+	if value, ok := h.connNAT.Load("223.254.0.100"); !ok || value != route {
+		t.Fatalf("expect route of client is kept after reconnect")
+	}
+	// old conn of session is not writable
+	if _, err = first.WriteTo(packet, nil); err == nil {
+		t.Fatalf("expect write to old conn of session failed")
+	}
+}
+
+func TestReplayBuffer(t *testing.T) {
+	var r replayBuffer
+	frame := make([]byte, sessionReplayBytes/4)
+	for i := 0; i < 6; i++ {
+		r.add(frame)
+	}
+	if r.next != 6 || len(r.frames) != 4 {
+		t.Fatalf("expect 4 recent frames of 6, but got %d of %d", len(r.frames), r.next)
+	}
+	if frames := r.since(5); len(frames) != 1 {
+		t.Fatalf("expect 1 frame to replay, but got %d", len(frames))
+	}
+	if frames := r.since(0); len(frames) != 4 {
+		t.Fatalf("expect lost frames are skipped, but got %d", len(frames))
+	}
+	if frames := r.since(6); len(frames) != 0 {
+		t.Fatalf("expect nothing to replay, but got %d", len(frames))
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PeerStats counters of every tunnel peer, keyed by tun ip of peer, ipv6 of peer is counted as its ipv4 once known.
+// traffic manager count packets of clients, client count packets of local tun device
+var PeerStats = NewPeerStatsRegistry()
+
+// PeerStat snapshot of one peer, tx is sent to peer and rx is received from peer, rate is bytes per second
+type PeerStat struct {
+	IP        string
+	TxPackets uint64
+	TxBytes   uint64
+	RxPackets uint64
+	RxBytes   uint64
+	Drops     uint64
+	TxRate    uint64
+	RxRate    uint64
+	LastSeen  time.Time
+	RTT       time.Duration
+}
+
+type peerCounter struct {
+	txPackets atomic.Uint64
+	txBytes   atomic.Uint64
+	rxPackets atomic.Uint64
+	rxBytes   atomic.Uint64
+	drops     atomic.Uint64
+	txRate    atomic.Uint64
+	rxRate    atomic.Uint64
+	// unix nano
+	lastSeen atomic.Int64
+	pingAt   atomic.Int64
+	rtt      atomic.Int64
+
+	// bytes of last second, for calculating rate
+	lastTxBytes uint64
+	lastRxBytes uint64
+}
+
+type PeerStatsRegistry struct {
+	lock  sync.RWMutex
+	peers map[string]*peerCounter
+	// map[ipv6]ipv4
+	aliases map[string]string
+	once    sync.Once
+}
+
+func NewPeerStatsRegistry() *PeerStatsRegistry {
+	return &PeerStatsRegistry{
+		peers:   map[string]*peerCounter{},
+		aliases: map[string]string{},
+	}
+}
+
+// Alias count packets of ipv6 as ipv4
+func (r *PeerStatsRegistry) Alias(ipv6, ipv4 net.IP) {
+	if ipv6 == nil || ipv4 == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.aliases[ipv6.String()] = ipv4.String()
+}
+
+// Key identity of peer, ipv6 of peer is identified as its ipv4
+func (r *PeerStatsRegistry) Key(ip net.IP) string {
+	key := ip.String()
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if alias, ok := r.aliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+func (r *PeerStatsRegistry) get(ip net.IP, create bool) *peerCounter {
+	key := r.Key(ip)
+	r.lock.RLock()
+	c := r.peers[key]
+	r.lock.RUnlock()
+	if c != nil || !create {
+		return c
+	}
+	r.once.Do(func() {
+		go r.calculateRate()
+	})
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if c = r.peers[key]; c == nil {
+		c = &peerCounter{}
+		r.peers[key] = c
+	}
+	return c
+}
+
+// Tx packet is sent to peer
+func (r *PeerStatsRegistry) Tx(ip net.IP, length int) {
+	c := r.get(ip, true)
+	c.txPackets.Add(1)
+	c.txBytes.Add(uint64(length))
+}
+
+// Rx packet is received from peer
+func (r *PeerStatsRegistry) Rx(ip net.IP, length int) {
+	c := r.get(ip, true)
+	c.rxPackets.Add(1)
+	c.rxBytes.Add(uint64(length))
+	c.lastSeen.Store(time.Now().UnixNano())
+}
+
+// Drop packet to or from known peer is dropped
+func (r *PeerStatsRegistry) Drop(ip net.IP) {
+	if c := r.get(ip, false); c != nil {
+		c.drops.Add(1)
+	}
+}
+
+// Ping heartbeat is sent
+func (r *PeerStatsRegistry) Ping(ip net.IP) {
+	r.get(ip, true).pingAt.Store(time.Now().UnixNano())
+}
+
+// Pong heartbeat reply is received, rtt is duration since last heartbeat
+func (r *PeerStatsRegistry) Pong(ip net.IP) {
+	c := r.get(ip, true)
+	if pingAt := c.pingAt.Load(); pingAt != 0 {
+		c.rtt.Store(time.Now().UnixNano() - pingAt)
+	}
+}
+
+// Remove peer is gone
+func (r *PeerStatsRegistry) Remove(ip net.IP) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.peers, ip.String())
+	for k, v := range r.aliases {
+		if k == ip.String() || v == ip.String() {
+			delete(r.aliases, k)
+		}
+	}
+}
+
+func (r *PeerStatsRegistry) List() []PeerStat {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var result = make([]PeerStat, 0, len(r.peers))
+	for ip, c := range r.peers {
+		stat := PeerStat{
+			IP:        ip,
+			TxPackets: c.txPackets.Load(),
+			TxBytes:   c.txBytes.Load(),
+			RxPackets: c.rxPackets.Load(),
+			RxBytes:   c.rxBytes.Load(),
+			Drops:     c.drops.Load(),
+			TxRate:    c.txRate.Load(),
+			RxRate:    c.rxRate.Load(),
+			RTT:       time.Duration(c.rtt.Load()),
+		}
+		if lastSeen := c.lastSeen.Load(); lastSeen != 0 {
+			stat.LastSeen = time.Unix(0, lastSeen)
+		}
+		result = append(result, stat)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].IP < result[j].IP
+	})
+	return result
+}
+
+func (r *PeerStatsRegistry) calculateRate() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		r.lock.RLock()
+		for _, c := range r.peers {
+			tx, rx := c.txBytes.Load(), c.rxBytes.Load()
+			c.txRate.Store(tx - c.lastTxBytes)
+			c.rxRate.Store(rx - c.lastRxBytes)
+			c.lastTxBytes, c.lastRxBytes = tx, rx
+		}
+		r.lock.RUnlock()
+	}
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"net"
+	"testing"
+)
+
+func TestPeerStats(t *testing.T) {
+	r := NewPeerStatsRegistry()
+	ipv4, ipv6 := net.ParseIP("223.254.0.100"), net.ParseIP("efff:ffff:ffff:ffff:ffff:ffff:ffff:9999")
+	r.Alias(ipv6, ipv4)
+	r.Tx(ipv4, 100)
+	r.Tx(ipv6, 50)
+	r.Rx(ipv4, 10)
+	r.Drop(ipv4)
+	// unknown peer is not counted
+	r.Drop(net.ParseIP("223.254.0.101"))
+	r.Ping(ipv4)
+	r.Pong(ipv4)
+
+	list := r.List()
+	if len(list) != 1 {
+		t.Fatalf("expect 1 peer, but got %d", len(list))
+	}
+	stat := list[0]
+	if stat.IP != ipv4.String() || stat.TxPackets != 2 || stat.TxBytes != 150 || stat.RxPackets != 1 ||
+		stat.RxBytes != 10 || stat.Drops != 1 || stat.LastSeen.IsZero() || stat.RTT == 0 {
+		t.Fatalf("invalid stats: %+v", stat)
+	}
+	r.Remove(ipv4)
+	if len(r.List()) != 0 {
+		t.Fatalf("expect no peer after remove")
+	}
+}
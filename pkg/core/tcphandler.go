@@ -43,6 +43,8 @@ type fakeUdpHandler struct {
 	// map[srcIP]net.Conn
 	connNAT *sync.Map
 	ch      chan *datagramPacket
+	// forwarded conn is from other replica of traffic manager, not from client
+	forwarded bool
 }
 
 func TCPHandler() Handler {
@@ -52,25 +54,50 @@ func TCPHandler() Handler {
 	}
 }
 
+// PeerHandler handle packets forwarded by other replicas, replies are routed back through the same conn
+func PeerHandler() Handler {
+	return &fakeUdpHandler{
+		connNAT:   RouteConnNAT,
+		ch:        Chan,
+		forwarded: true,
+	}
+}
+
 func (h *fakeUdpHandler) Handle(ctx context.Context, tcpConn net.Conn) {
 	defer tcpConn.Close()
 	log.Debugf("[tcpserver] %s -> %s\n", tcpConn.RemoteAddr(), tcpConn.LocalAddr())
 
-	defer func(addr net.Addr) {
-		var keys []string
-		h.connNAT.Range(func(key, value any) bool {
-			if value.(net.Conn) == tcpConn {
-				keys = append(keys, key.(string))
+	// raw is tcp conn of this handle, tcpConn routes packets back to client, it is session if client resumes session
+	raw := tcpConn
+	var sess *serverSession
+	var group *streamGroup
+	var auth *authConn
+	// tun ip of clients which are published as local clients by this conn
+	var claimed = map[string]struct{}{}
+	if TunnelKeys.Enabled() {
+		auth = &authConn{Conn: tcpConn}
+		tcpConn = auth
+	}
+
+	defer func() {
+		// routes of session are kept until session is expired, but other replicas should not forward packets to
+		// this replica while client is away, it may reconnect to any replica
+		if sess != nil {
+			sess.detach(raw)
+			h.releaseLocal(sess.route)
+			return
+		}
+		// routes of group are kept until all streams of it are gone
+		if group != nil {
+			if StreamGroups.detach(group, raw) {
+				h.removeRoutes(group.route)
 			}
-			return true
-		})
-		for _, key := range keys {
-			h.connNAT.Delete(key)
+			return
 		}
-		log.Debugf("[tcpserver] delete conn %s from globle routeConnNAT, deleted count %d", addr, len(keys))
-	}(tcpConn.LocalAddr())
+		h.removeRoutes(tcpConn)
+	}()
 
-	for {
+	for first := true; ; first = false {
 		select {
 		case <-ctx.Done():
 			return
@@ -78,12 +105,68 @@ func (h *fakeUdpHandler) Handle(ctx context.Context, tcpConn net.Conn) {
 		}
 
 		b := config.LPool.Get().([]byte)[:]
-		dgram, err := readDatagramPacketServer(tcpConn, b[:])
+		dgram, err := readDatagramPacketServer(raw, b[:])
 		if err != nil {
-			log.Debugf("[tcpserver] %s -> 0 : %v", tcpConn.RemoteAddr(), err)
+			log.Debugf("[tcpserver] %s -> 0 : %v", raw.RemoteAddr(), err)
 			return
 		}
 
+		if first && !h.forwarded && isSessionHello(dgram.Data[:dgram.DataLength]) {
+			sess, err = Sessions.attach(dgram.Data[:dgram.DataLength], raw, func(s *serverSession) {
+				s.route = s
+				if TunnelKeys.Enabled() {
+					s.route = &authConn{Conn: s}
+				}
+				s.onClose = func() {
+					h.removeRoutes(s.route)
+				}
+			})
+			config.LPool.Put(b[:])
+			if err != nil {
+				log.Debugf("[tcpserver] %s session handshake failed: %v", raw.RemoteAddr(), err)
+				return
+			}
+			tcpConn = sess.route
+			auth, _ = tcpConn.(*authConn)
+			continue
+		}
+		if first && !h.forwarded && isStreamHello(dgram.Data[:dgram.DataLength]) {
+			group, err = StreamGroups.attach(dgram.Data[:dgram.DataLength], raw, func(g *streamGroup) {
+				g.route = g
+				if TunnelKeys.Enabled() {
+					g.route = &authConn{Conn: g}
+				}
+			})
+			config.LPool.Put(b[:])
+			if err != nil {
+				log.Debugf("[tcpserver] %s stream hello failed: %v", raw.RemoteAddr(), err)
+				return
+			}
+			tcpConn = group.route
+			auth, _ = tcpConn.(*authConn)
+			continue
+		}
+		if sess != nil {
+			sess.received()
+		}
+
+		// source ip is tun ip of client, replies to it are routed through this conn
+		var srcOwned = true
+		if auth != nil {
+			var packet []byte
+			if h.forwarded {
+				packet, srcOwned, err = auth.authenticatePeer(dgram.Data[:dgram.DataLength])
+			} else {
+				packet, err = auth.authenticate(dgram.Data[:dgram.DataLength])
+			}
+			if err != nil {
+				log.Debugf("[tcpserver] %s reject: %v", tcpConn.RemoteAddr(), err)
+				config.LPool.Put(b[:])
+				return
+			}
+			dgram.DataLength = uint16(copy(dgram.Data, packet))
+		}
+
 		var src net.IP
 		bb := dgram.Data[:dgram.DataLength]
 		if util.IsIPv4(bb) {
@@ -94,6 +177,18 @@ func (h *fakeUdpHandler) Handle(ctx context.Context, tcpConn net.Conn) {
 			log.Errorf("[tcpserver] unknown packet")
 			continue
 		}
+		PeerStats.Rx(src, int(dgram.DataLength))
+		// packets forwarded by other replica are already limited by it
+		if !h.forwarded {
+			if err = RateLimits.WaitRx(ctx, src, int(dgram.DataLength)); err != nil {
+				config.LPool.Put(b[:])
+				return
+			}
+		}
+		if !srcOwned {
+			h.ch <- dgram
+			continue
+		}
 		value, loaded := h.connNAT.LoadOrStore(src.String(), tcpConn)
 		if loaded {
 			if tcpConn != value.(net.Conn) {
@@ -104,10 +199,45 @@ func (h *fakeUdpHandler) Handle(ctx context.Context, tcpConn net.Conn) {
 		} else {
 			log.Debugf("[tcpserver] new routeConnNAT: %s -> %s-%s", src, tcpConn.LocalAddr(), tcpConn.RemoteAddr())
 		}
+		// resumed session keeps its routes, so claim it again
+		if _, ok := claimed[src.String()]; !ok && !h.forwarded {
+			claimed[src.String()] = struct{}{}
+			PeerRoutes.addLocal(src)
+		}
 		h.ch <- dgram
 	}
 }
 
+// removeRoutes client of conn is gone
+func (h *fakeUdpHandler) removeRoutes(conn net.Conn) {
+	var keys []string
+	h.connNAT.Range(func(key, value any) bool {
+		if value.(net.Conn) == conn {
+			keys = append(keys, key.(string))
+		}
+		return true
+	})
+	for _, key := range keys {
+		h.connNAT.Delete(key)
+		if !h.forwarded {
+			PeerRoutes.removeLocal(net.ParseIP(key))
+		}
+		RateLimits.Remove(net.ParseIP(key))
+		PeerStats.Remove(net.ParseIP(key))
+	}
+	log.Debugf("[tcpserver] delete conn %s from globle routeConnNAT, deleted count %d", conn.LocalAddr(), len(keys))
+}
+
+// releaseLocal client of conn is away, keep routes but stop publishing it as local client
+func (h *fakeUdpHandler) releaseLocal(conn net.Conn) {
+	h.connNAT.Range(func(key, value any) bool {
+		if value.(net.Conn) == conn {
+			PeerRoutes.removeLocal(net.ParseIP(key.(string)))
+		}
+		return true
+	})
+}
+
 // fake udp connect over tcp
 type fakeUDPTunnelConn struct {
 	// tcp connection
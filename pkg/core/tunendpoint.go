@@ -28,6 +28,9 @@ type tunEndpoint struct {
 	once     sync.Once
 	endpoint *channel.Endpoint
 	engine   config.Engine
+	mtu      uint32
+	// path mtu of tunnel, packets larger than it are rejected
+	pmtu *pathMTU
 
 	in  chan<- *DataElem
 	out chan *DataElem
@@ -47,7 +50,7 @@ func (e *tunEndpoint) WritePackets(p stack.PacketBufferList) (int, tcpip.Error)
 // physical network doesn't exist, the limit is generally 64k, which
 // includes the maximum size of an IP packet.
 func (e *tunEndpoint) MTU() uint32 {
-	return uint32(config.DefaultMTU)
+	return e.mtu
 }
 
 // MaxHeaderLength returns the maximum size the data link (and
@@ -146,6 +149,13 @@ func (e *tunEndpoint) Attach(dispatcher stack.NetworkDispatcher) {
 					log.Debugf("[TUN-gvisor] unknown packet version %d", version)
 					continue
 				}
+				// packet can not pass through tunnel, tell local tcp stack to lower mss
+				if reply := e.pmtu.tooBig(bytes[:read]); reply != nil {
+					log.Debugf("[TUN] packet too big, SRC: %s, DST: %s, Length: %d, MTU: %d", src, dst, read, e.pmtu.Get())
+					n := copy(bytes, reply)
+					e.out <- NewDataElem(bytes[:], n, nil, nil)
+					continue
+				}
 				// only tcp and udp needs to distinguish transport engine
 				//   gvisor: all network use gvisor
 				//   mix: cluster network use gvisor, diy network use raw
@@ -208,13 +218,15 @@ func (e *tunEndpoint) AddHeader(ptr stack.PacketBufferPtr) {
 	return
 }
 
-func NewTunEndpoint(ctx context.Context, tun net.Conn, mtu uint32, engine config.Engine, in chan<- *DataElem, out chan *DataElem) stack.LinkEndpoint {
+func NewTunEndpoint(ctx context.Context, tun net.Conn, mtu uint32, engine config.Engine, in chan<- *DataElem, out chan *DataElem, pmtu *pathMTU) stack.LinkEndpoint {
 	addr, _ := tcpip.ParseMACAddress("02:03:03:04:05:06")
 	return &tunEndpoint{
 		ctx:      ctx,
 		tun:      tun,
 		endpoint: channel.New(tcp.DefaultReceiveBufferSize, mtu, addr),
 		engine:   engine,
+		mtu:      mtu,
+		pmtu:     pmtu,
 		in:       in,
 		out:      out,
 	}
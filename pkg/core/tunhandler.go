@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"net"
@@ -21,6 +22,8 @@ const (
 	MaxSize   = 1000
 	MaxThread = 10
 	MaxConn   = 1
+
+	heartbeatID = 3842
 )
 
 type tunHandler struct {
@@ -254,6 +257,7 @@ func heartbeats(tun net.Conn, in chan<- *DataElem) {
 		return
 	}
 
+	PeerStats.Alias(srcIPv6, srcIPv4)
 	var bytes []byte
 	var bytes6 []byte
 
@@ -282,6 +286,7 @@ func heartbeats(tun net.Conn, in chan<- *DataElem) {
 				var src, dst net.IP
 				if index == 0 {
 					src, dst = srcIPv4, config.RouterIP
+					PeerStats.Ping(srcIPv4)
 				} else {
 					src, dst = srcIPv6, config.RouterIP6
 				}
@@ -297,11 +302,24 @@ func heartbeats(tun net.Conn, in chan<- *DataElem) {
 	}
 }
 
+// isHeartbeatReply icmp echo reply of heartbeats from router ip
+func isHeartbeatReply(packet []byte) bool {
+	if !util.IsIPv4(packet) || len(packet) < 20 || packet[9] != byte(layers.IPProtocolICMPv4) {
+		return false
+	}
+	ihl := int(packet[0]&0x0f) * 4
+	if len(packet) < ihl+8 || !config.RouterIP.Equal(net.IP(packet[12:16])) {
+		return false
+	}
+	icmp := packet[ihl:]
+	return icmp[0] == layers.ICMPv4TypeEchoReply && binary.BigEndian.Uint16(icmp[4:6]) == heartbeatID
+}
+
 func genICMPPacket(src net.IP, dst net.IP) ([]byte, error) {
 	buf := gopacket.NewSerializeBuffer()
 	icmpLayer := layers.ICMPv4{
 		TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoRequest, 0),
-		Id:       3842,
+		Id:       heartbeatID,
 		Seq:      1,
 	}
 	ipLayer := layers.IPv4{
@@ -387,6 +405,9 @@ func (h *tunHandler) HandleServer(ctx context.Context, tun net.Conn) {
 				log.Debugf("[udp] can not listen %s, err: %v", h.node.Addr, err)
 				return
 			}
+			if TunnelKeys.Enabled() {
+				packetConn = newAuthPacketConn(packetConn)
+			}
 			err = transportTun(ctx, tunInbound, tunOutbound, packetConn, h.routeNAT, h.routeConnNAT)
 			if err != nil {
 				log.Debugf("[tun] %s: %v", tun.LocalAddr(), err)
@@ -444,6 +465,8 @@ type Peer struct {
 	// map[srcIP]net.Conn
 	// 	routeConnNAT sync.Map
 	routeConnNAT *sync.Map
+	// packets to clients
+	queues *clientQueues
 
 	errChan chan error
 }
@@ -512,6 +535,12 @@ func (p *Peer) parseHeader() {
 			continue
 		}
 
+		PeerStats.Rx(e.src, e.length)
+		if !RateLimits.AllowRx(e.src, e.length) {
+			PeerStats.Drop(e.src)
+			config.LPool.Put(e.data[:])
+			continue
+		}
 		if firstIPv4 || firstIPv6 {
 			if util.IsIPv4(e.data[:e.length]) {
 				firstIPv4 = false
@@ -522,6 +551,7 @@ func (p *Peer) parseHeader() {
 				log.Debugf("[tun] find route: %s -> %s", e.src, e.from)
 			} else {
 				log.Debugf("[tun] new route: %s -> %s", e.src, e.from)
+				PeerRoutes.addLocal(e.src)
 			}
 		}
 		p.parsedConnInfo <- e
@@ -530,22 +560,15 @@ func (p *Peer) parseHeader() {
 
 func (p *Peer) routePeer() {
 	for e := range p.parsedConnInfo {
+		Captures.Tap(e.data[:e.length])
 		if routeToAddr := p.routeNAT.RouteTo(e.dst); routeToAddr != nil {
 			log.Debugf("[tun] find route: %s -> %s", e.dst, routeToAddr)
-			_, err := p.conn.WriteTo(e.data[:e.length], routeToAddr)
-			config.LPool.Put(e.data[:])
-			if err != nil {
-				p.sendErr(err)
-				return
-			}
+			p.queues.enqueue(&queuedPacket{DataElem: NewDataElem(e.data, e.length, e.src, e.dst), addr: routeToAddr})
 		} else if conn, ok := p.routeConnNAT.Load(e.dst.String()); ok {
-			dgram := newDatagramPacket(e.data[:e.length])
-			if err := dgram.Write(conn.(net.Conn)); err != nil {
-				log.Debugf("[tcpserver] udp-tun %s <- %s : %s", conn.(net.Conn).RemoteAddr(), dgram.Addr(), err)
-				p.sendErr(err)
-				return
-			}
-			config.LPool.Put(e.data[:])
+			p.queues.enqueue(&queuedPacket{DataElem: NewDataElem(e.data, e.length, e.src, e.dst), conn: conn.(net.Conn)})
+		} else if addr := PeerRoutes.lookup(e.dst); addr != "" {
+			log.Debugf("[tun] find peer route: %s -> %s", e.dst, addr)
+			PeerRoutes.forward(addr, e.src, NewDataElem(e.data, e.length, e.src, e.dst))
 		} else {
 			p.tunOutbound <- &DataElem{
 				data:   e.data,
@@ -559,25 +582,18 @@ func (p *Peer) routePeer() {
 
 func (p *Peer) routeTUN() {
 	for e := range p.tunInbound {
+		Captures.Tap(e.data[:e.length])
 		if addr := p.routeNAT.RouteTo(e.dst); addr != nil {
 			log.Debugf("[tun] find route: %s -> %s", e.dst, addr)
-			_, err := p.conn.WriteTo(e.data[:e.length], addr)
-			config.LPool.Put(e.data[:])
-			if err != nil {
-				log.Debugf("[tun] can not route: %s -> %s", e.dst, addr)
-				p.sendErr(err)
-				return
-			}
+			p.queues.enqueue(&queuedPacket{DataElem: e, addr: addr})
 		} else if conn, ok := p.routeConnNAT.Load(e.dst.String()); ok {
-			dgram := newDatagramPacket(e.data[:e.length])
-			err := dgram.Write(conn.(net.Conn))
-			config.LPool.Put(e.data[:])
-			if err != nil {
-				log.Debugf("[tcpserver] udp-tun %s <- %s : %s", conn.(net.Conn).RemoteAddr(), dgram.Addr(), err)
-				p.sendErr(err)
-				return
-			}
+			p.queues.enqueue(&queuedPacket{DataElem: e, conn: conn.(net.Conn)})
+		} else if addr := PeerRoutes.lookup(e.dst); addr != "" {
+			// client connects to other replica, packet is sealed by key of destination client
+			log.Debugf("[tun] find peer route: %s -> %s", e.dst, addr)
+			PeerRoutes.forward(addr, e.dst, e)
 		} else {
+			PeerStats.Drop(e.dst)
 			config.LPool.Put(e.data[:])
 			log.Debug(fmt.Errorf("[tun] no route for %s -> %s", e.src, e.dst))
 		}
@@ -594,11 +610,29 @@ func (p *Peer) Start() {
 	go p.routeTUN()
 }
 
+// writeToClient write packet to client over udp or tcp, error of udp conn is error of peer
+func (p *Peer) writeToClient(packet *queuedPacket) error {
+	if packet.addr != nil {
+		_, err := p.conn.WriteTo(packet.data[:packet.length], packet.addr)
+		if err != nil {
+			p.sendErr(err)
+		}
+		return err
+	}
+	err := writeDatagramPacket(packet.conn, packet.data[:packet.length])
+	if err != nil {
+		log.Debugf("[tcpserver] udp-tun %s <- %s : %s", packet.conn.RemoteAddr(), packet.src, err)
+	}
+	return err
+}
+
 func (p *Peer) Close() {
 	p.conn.Close()
 }
 
 func transportTun(ctx context.Context, tunInbound <-chan *DataElem, tunOutbound chan<- *DataElem, packetConn net.PacketConn, nat *NAT, connNAT *sync.Map) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	p := &Peer{
 		conn:           packetConn,
 		thread:         MaxThread,
@@ -610,6 +644,7 @@ func transportTun(ctx context.Context, tunInbound <-chan *DataElem, tunOutbound
 		routeConnNAT:   connNAT,
 		errChan:        make(chan error, 2),
 	}
+	p.queues = newClientQueues(ctx, p.writeToClient)
 
 	defer p.Close()
 	p.Start()
@@ -9,6 +9,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/util"
 )
 
 func (h *tunHandler) HandleClient(ctx context.Context, tun net.Conn) {
@@ -21,8 +22,17 @@ func (h *tunHandler) HandleClient(ctx context.Context, tun net.Conn) {
 	in := make(chan *DataElem, MaxSize)
 	out := make(chan *DataElem, MaxSize)
 	engine := h.node.Get(config.ConfigKubeVPNTransportEngine)
-	endpoint := NewTunEndpoint(ctx, tun, uint32(config.DefaultMTU), config.Engine(engine), in, out)
-	stack := NewStack(ctx, endpoint)
+	mtu := h.node.GetInt("mtu")
+	if mtu <= 0 {
+		mtu = config.DefaultMTU
+	}
+	pmtu := newPathMTU(in)
+	if addr, ok := tun.LocalAddr().(*net.IPAddr); ok {
+		PathMTUs.add(addr.IP, pmtu)
+		defer PathMTUs.remove(addr.IP, pmtu)
+	}
+	endpoint := NewTunEndpoint(ctx, tun, uint32(mtu), config.Engine(engine), in, out, pmtu)
+	stack := NewStack(ctx, endpoint, h.node.Get("gtcp"), h.node.Get("gudp"))
 	go stack.Wait()
 
 	d := &ClientDevice{
@@ -45,7 +55,7 @@ func (h *tunHandler) HandleClient(ctx context.Context, tun net.Conn) {
 				time.Sleep(time.Second * 2)
 				continue
 			}
-			err = transportTunClient(ctx, tunInbound, tunOutbound, packetConn, remoteAddr)
+			err = transportTunClient(ctx, tunInbound, tunOutbound, packetConn, remoteAddr, pmtu)
 			if err != nil {
 				log.Debugf("[tun-client] %s: %v", tun.LocalAddr(), err)
 			}
@@ -82,7 +92,7 @@ func getRemotePacketConn(ctx context.Context, chain *Chain) (packetConn net.Pack
 	return
 }
 
-func transportTunClient(ctx context.Context, tunInbound <-chan *DataElem, tunOutbound chan<- *DataElem, packetConn net.PacketConn, remoteAddr net.Addr) error {
+func transportTunClient(ctx context.Context, tunInbound <-chan *DataElem, tunOutbound chan<- *DataElem, packetConn net.PacketConn, remoteAddr net.Addr, pmtu *pathMTU) error {
 	errChan := make(chan error, 2)
 	defer packetConn.Close()
 
@@ -92,12 +102,15 @@ func transportTunClient(ctx context.Context, tunInbound <-chan *DataElem, tunOut
 				tunOutbound <- e
 				continue
 			}
+			Captures.Tap(e.data[:e.length])
 			_, err := packetConn.WriteTo(e.data[:e.length], remoteAddr)
 			config.LPool.Put(e.data[:])
 			if err != nil {
+				PeerStats.Drop(e.src)
 				errChan <- err
 				return
 			}
+			PeerStats.Tx(e.src, e.length)
 		}
 	}()
 
@@ -109,6 +122,17 @@ func transportTunClient(ctx context.Context, tunInbound <-chan *DataElem, tunOut
 				errChan <- err
 				return
 			}
+			Captures.Tap(b[:n])
+			if pmtu.handleReply(b[:n]) {
+				config.LPool.Put(b[:])
+				continue
+			}
+			if dst := parseDst(b[:n]); dst != nil {
+				PeerStats.Rx(dst, n)
+				if isHeartbeatReply(b[:n]) {
+					PeerStats.Pong(dst)
+				}
+			}
 			tunOutbound <- &DataElem{data: b[:], length: n}
 		}
 	}()
@@ -146,3 +170,12 @@ func (d *ClientDevice) Start(ctx context.Context) {
 func (d *ClientDevice) SetTunInboundHandler(handler func(tunInbound <-chan *DataElem, tunOutbound chan<- *DataElem)) {
 	d.tunInboundHandler = handler
 }
+
+func parseDst(packet []byte) net.IP {
+	if util.IsIPv4(packet) && len(packet) >= 20 {
+		return net.IP(packet[16:20])
+	} else if util.IsIPv6(packet) && len(packet) >= 40 {
+		return net.IP(packet[24:40])
+	}
+	return nil
+}
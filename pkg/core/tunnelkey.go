@@ -0,0 +1,524 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/chacha20poly1305"
+	"k8s.io/apimachinery/pkg/util/cache"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/util"
+)
+
+const (
+	tunnelIDSize     = net.IPv4len
+	tunnelHeaderSize = tunnelIDSize + chacha20poly1305.NonceSize
+	// tunnelHandshakeSize sealed unix time of client, client tun ipv4 [4]byte | nonce [12]byte | sealed time [8+16]byte
+	tunnelHandshakeSize = tunnelHeaderSize + 8 + chacha20poly1305.Overhead
+	// tunnelHandshakeSkew handshake is rejected if clock of client is too far away from traffic manager
+	tunnelHandshakeSkew = time.Minute * 2
+)
+
+// TunnelKey key of one tunnel client, client is identified by its tun ipv4, packet between client and traffic
+// manager is sealed as: client tun ipv4 [4]byte | nonce [12]byte | chacha20poly1305 ciphertext
+type TunnelKey struct {
+	IPv4 string
+	IPv6 string
+	Key  []byte
+	// ExpireAt nil means key is valid until ip is released
+	ExpireAt *time.Time
+}
+
+func NewTunnelKey(ipv4, ipv6 net.IP, lifetime time.Duration) (*TunnelKey, error) {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate tunnel key, err: %v", err)
+	}
+	k := &TunnelKey{IPv4: ipv4.String(), Key: key}
+	if ipv6 != nil {
+		k.IPv6 = ipv6.String()
+	}
+	if lifetime > 0 {
+		expireAt := time.Now().Add(lifetime)
+		k.ExpireAt = &expireAt
+	}
+	return k, nil
+}
+
+func (k *TunnelKey) IsExpired(now time.Time) bool {
+	return k.ExpireAt != nil && now.After(*k.ExpireAt)
+}
+
+// Token client use token to seal packets, it contains tun ipv4 and key
+func (k *TunnelKey) Token() string {
+	return base64.RawURLEncoding.EncodeToString(append(net.ParseIP(k.IPv4).To4(), k.Key...))
+}
+
+func (k *TunnelKey) hasIP(ip net.IP) bool {
+	return ip.Equal(net.ParseIP(k.IPv4)) || (len(k.IPv6) != 0 && ip.Equal(net.ParseIP(k.IPv6)))
+}
+
+// TunnelKeys keys of clients, once enabled, traffic manager only accept packets sealed by these keys
+var TunnelKeys = &tunnelKeyStore{ciphers: map[string]*tunnelCipher{}}
+
+type tunnelKeyStore struct {
+	lock    sync.RWMutex
+	enabled atomic.Bool
+	// map[tun ipv4]*tunnelCipher
+	ciphers map[string]*tunnelCipher
+}
+
+func (s *tunnelKeyStore) Enable() {
+	s.enabled.Store(true)
+}
+
+func (s *tunnelKeyStore) Enabled() bool {
+	return s.enabled.Load()
+}
+
+// Update replace all keys, keys is map[tun ipv4]*TunnelKey
+func (s *tunnelKeyStore) Update(keys map[string]*TunnelKey) {
+	ciphers := make(map[string]*tunnelCipher, len(keys))
+	for _, k := range keys {
+		c, err := newTunnelCipher(net.ParseIP(k.IPv4), k.Key)
+		if err != nil {
+			log.Errorf("invalid tunnel key of %s: %v", k.IPv4, err)
+			continue
+		}
+		c.key = k
+		ciphers[c.String()] = c
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ciphers = ciphers
+}
+
+func (s *tunnelKeyStore) load(id []byte) *tunnelCipher {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.ciphers[net.IP(id).String()]
+}
+
+// lookup cipher of client by tun ipv4 or ipv6
+func (s *tunnelKeyStore) lookup(ip net.IP) *tunnelCipher {
+	if ip.To4() != nil {
+		return s.load(ip.To4())
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, c := range s.ciphers {
+		if c.key.hasIP(ip) {
+			return c
+		}
+	}
+	return nil
+}
+
+type tunnelCipher struct {
+	id   []byte
+	aead cipher.AEAD
+	// key of client, only server side has it
+	key *TunnelKey
+}
+
+func newTunnelCipher(ipv4 net.IP, key []byte) (*tunnelCipher, error) {
+	if ipv4.To4() == nil {
+		return nil, fmt.Errorf("tunnel client id %s is not ipv4", ipv4)
+	}
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return &tunnelCipher{id: ipv4.To4(), aead: aead}, nil
+}
+
+func parseTunnelKeyToken(token string) (*tunnelCipher, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) != tunnelIDSize+chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("invalid tunnel key")
+	}
+	return newTunnelCipher(b[:tunnelIDSize], b[tunnelIDSize:])
+}
+
+func (c *tunnelCipher) String() string {
+	return net.IP(c.id).String()
+}
+
+// seal packet and append to dst
+func (c *tunnelCipher) seal(dst, packet []byte) []byte {
+	dst = append(dst, c.id...)
+	dst = append(dst, make([]byte, chacha20poly1305.NonceSize)...)
+	nonce := dst[len(dst)-chacha20poly1305.NonceSize:]
+	_, _ = rand.Read(nonce)
+	return c.aead.Seal(dst, nonce, packet, c.id)
+}
+
+// open sealed packet in place, returns plaintext
+func (c *tunnelCipher) open(b []byte) ([]byte, error) {
+	if len(b) < tunnelHeaderSize || !bytes.Equal(b[:tunnelIDSize], c.id) {
+		return nil, fmt.Errorf("invalid sealed packet")
+	}
+	return c.aead.Open(b[tunnelHeaderSize:tunnelHeaderSize], b[tunnelIDSize:tunnelHeaderSize], b[tunnelHeaderSize:], c.id)
+}
+
+// openTunnelPacket server side open sealed packet with key of client, source ip of packet must be tun ip of client
+func openTunnelPacket(b []byte) (*tunnelCipher, []byte, error) {
+	c, packet, src, _, err := openSealedPacket(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if src == nil || !c.key.hasIP(src) {
+		return nil, nil, fmt.Errorf("source ip %s not match tunnel client %s", src, c)
+	}
+	return c, packet, nil
+}
+
+// openPeerPacket open packet forwarded by other replica, it is sealed by key of source client, or by key of
+// destination client if it comes from tun device of other replica, srcOwned reports source ip is tun ip of client
+func openPeerPacket(b []byte) (c *tunnelCipher, packet []byte, srcOwned bool, err error) {
+	var src, dst net.IP
+	c, packet, src, dst, err = openSealedPacket(b)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if src != nil && c.key.hasIP(src) {
+		return c, packet, true, nil
+	}
+	if dst != nil && c.key.hasIP(dst) {
+		return c, packet, false, nil
+	}
+	return nil, nil, false, fmt.Errorf("packet %s -> %s not match tunnel client %s", src, dst, c)
+}
+
+// openSealedPacket open sealed packet with key of client, returns source and destination ip of packet
+func openSealedPacket(b []byte) (*tunnelCipher, []byte, net.IP, net.IP, error) {
+	if len(b) < tunnelHeaderSize {
+		return nil, nil, nil, nil, fmt.Errorf("invalid sealed packet")
+	}
+	c := TunnelKeys.load(b[:tunnelIDSize])
+	if c == nil {
+		return nil, nil, nil, nil, fmt.Errorf("tunnel key of %s not found", net.IP(b[:tunnelIDSize]))
+	}
+	if c.key.IsExpired(time.Now()) {
+		return nil, nil, nil, nil, fmt.Errorf("tunnel key of %s is expired", c)
+	}
+	packet, err := c.open(b)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to open packet from %s, err: %v", c, err)
+	}
+	var src, dst net.IP
+	if util.IsIPv4(packet) && len(packet) >= 20 {
+		src, dst = packet[12:16], packet[16:20]
+	} else if util.IsIPv6(packet) && len(packet) >= 40 {
+		src, dst = packet[8:24], packet[24:40]
+	}
+	return c, packet, src, dst, nil
+}
+
+// clientTunnelKeys current key of tunnel clients in this process, key is tun ipv4 of client. token in chain node is
+// the key issued at connect time, it is replaced once traffic manager issues a new one, eg: old one is expired
+var clientTunnelKeys = &clientTunnelKeyStore{keys: map[string]*atomic.Pointer[tunnelCipher]{}}
+
+type clientTunnelKeyStore struct {
+	lock sync.Mutex
+	// map[tun ipv4]*atomic.Pointer[tunnelCipher]
+	keys map[string]*atomic.Pointer[tunnelCipher]
+}
+
+// register key of chain node, it replaces key left by previous connection of same tun ipv4
+func (s *clientTunnelKeyStore) register(token string) (*atomic.Pointer[tunnelCipher], error) {
+	c, err := parseTunnelKeyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key, ok := s.keys[c.String()]
+	if !ok {
+		key = &atomic.Pointer[tunnelCipher]{}
+		s.keys[c.String()] = key
+	}
+	key.Store(c)
+	return key, nil
+}
+
+// current key of client which token is issued to, token itself if it is not registered
+func (s *clientTunnelKeyStore) current(token string) (*tunnelCipher, error) {
+	c, err := parseTunnelKeyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if key, ok := s.keys[c.String()]; ok {
+		return key.Load(), nil
+	}
+	return c, nil
+}
+
+// RotateTunnelKey replace key of client with the one which traffic manager issues again, tunnel and gvisor forwarders
+// of the client seal packets and handshakes with it from now on
+func RotateTunnelKey(token string) error {
+	c, err := parseTunnelKeyToken(token)
+	if err != nil {
+		return err
+	}
+	clientTunnelKeys.lock.Lock()
+	defer clientTunnelKeys.lock.Unlock()
+	key, ok := clientTunnelKeys.keys[c.String()]
+	if !ok {
+		return fmt.Errorf("tunnel key of %s is not used by any tunnel", c)
+	}
+	key.Store(c)
+	return nil
+}
+
+type tunnelKeyConnector struct {
+	connector Connector
+	key       *atomic.Pointer[tunnelCipher]
+}
+
+// TunnelKeyConnector seal packets with tunnel key which is issued by dhcp
+func TunnelKeyConnector(connector Connector, token string) (Connector, error) {
+	key, err := clientTunnelKeys.register(token)
+	if err != nil {
+		return nil, err
+	}
+	return &tunnelKeyConnector{connector: connector, key: key}, nil
+}
+
+func (c *tunnelKeyConnector) ConnectContext(ctx context.Context, conn net.Conn) (net.Conn, error) {
+	cc, err := c.connector.ConnectContext(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cc.(net.PacketConn); !ok {
+		return nil, fmt.Errorf("not a packet connection")
+	}
+	return &sealedConn{Conn: cc, key: c.key}, nil
+}
+
+// sealedConn client side fake udp conn, seal packet before write and open packet after read
+type sealedConn struct {
+	net.Conn
+	key *atomic.Pointer[tunnelCipher]
+}
+
+func (c *sealedConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	buf := config.LPool.Get().([]byte)[:]
+	defer config.LPool.Put(buf[:])
+	n, addr, err := c.Conn.(net.PacketConn).ReadFrom(buf[:])
+	if err != nil {
+		return 0, nil, err
+	}
+	packet, err := c.key.Load().open(buf[:n])
+	if err != nil {
+		return 0, nil, err
+	}
+	return copy(b, packet), addr, nil
+}
+
+func (c *sealedConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	buf := config.LPool.Get().([]byte)[:]
+	defer config.LPool.Put(buf[:])
+	_, err := c.Conn.(net.PacketConn).WriteTo(c.key.Load().seal(buf[:0], b), addr)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// authConn server side tcp conn of authenticated client
+type authConn struct {
+	net.Conn
+	cipher atomic.Pointer[tunnelCipher]
+}
+
+// authenticate open sealed packet, all packets of one conn must be sealed by same client
+func (c *authConn) authenticate(b []byte) ([]byte, error) {
+	cc, packet, err := openTunnelPacket(b)
+	if err != nil {
+		return nil, err
+	}
+	return packet, c.accept(cc)
+}
+
+// authenticatePeer open sealed packet forwarded by other replica, srcOwned is false if packet comes from tun device
+// of other replica, routes of its source ip must not be taken over by this conn
+func (c *authConn) authenticatePeer(b []byte) (packet []byte, srcOwned bool, err error) {
+	var cc *tunnelCipher
+	cc, packet, srcOwned, err = openPeerPacket(b)
+	if err != nil {
+		return nil, false, err
+	}
+	return packet, srcOwned, c.accept(cc)
+}
+
+func (c *authConn) accept(cc *tunnelCipher) error {
+	old := c.cipher.Load()
+	if old != nil && !bytes.Equal(old.id, cc.id) {
+		return fmt.Errorf("tunnel client changed from %s to %s", old, cc)
+	}
+	if old == nil {
+		PeerStats.Alias(net.ParseIP(cc.key.IPv6), net.ParseIP(cc.key.IPv4))
+	}
+	c.cipher.Store(cc)
+	return nil
+}
+
+// writeDatagramPacket write packet to tcp conn of client, packet is sealed if client is authenticated
+func writeDatagramPacket(conn net.Conn, data []byte) error {
+	c, ok := conn.(*authConn)
+	if !ok {
+		return newDatagramPacket(data).Write(flowConn(conn, data))
+	}
+	cc := c.cipher.Load()
+	if cc == nil {
+		return fmt.Errorf("tunnel client %s is not authenticated", c.RemoteAddr())
+	}
+	b := config.LPool.Get().([]byte)[:]
+	defer config.LPool.Put(b[:])
+	return newDatagramPacket(cc.seal(b[:0], data)).Write(flowConn(c.Conn, data))
+}
+
+// authPacketConn server side udp conn, drop packets which are not sealed by known client
+type authPacketConn struct {
+	net.PacketConn
+	// map[addr]*tunnelCipher
+	ciphers *sync.Map
+}
+
+func newAuthPacketConn(conn net.PacketConn) net.PacketConn {
+	return &authPacketConn{PacketConn: conn, ciphers: &sync.Map{}}
+}
+
+func (c *authPacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(b)
+		if err != nil {
+			return 0, nil, err
+		}
+		cc, packet, err := openTunnelPacket(b[:n])
+		if err != nil {
+			log.Debugf("[udp] drop packet from %s: %v", addr, err)
+			continue
+		}
+		c.ciphers.Store(addr.String(), cc)
+		return copy(b, packet), addr, nil
+	}
+}
+
+func (c *authPacketConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	value, ok := c.ciphers.Load(addr.String())
+	if !ok {
+		log.Debugf("[udp] drop packet to unauthenticated client %s", addr)
+		return len(b), nil
+	}
+	buf := config.LPool.Get().([]byte)[:]
+	defer config.LPool.Put(buf[:])
+	_, err := c.PacketConn.WriteTo(value.(*tunnelCipher).seal(buf[:0], b), addr)
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// seenHandshakes nonce of handshakes which are accepted, handshake can not be replayed
+var seenHandshakes = cache.NewLRUExpireCache(4096)
+
+// writeTunnelHandshake client seal current time with tunnel key, do nothing if token is empty
+func writeTunnelHandshake(conn net.Conn, token string) error {
+	if token == "" {
+		return nil
+	}
+	c, err := clientTunnelKeys.current(token)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(c.sealTime(time.Now()))
+	return err
+}
+
+// readTunnelHandshake server side, conn is accepted only if handshake is sealed by key of known client,
+// is fresh and is never seen before
+func readTunnelHandshake(conn net.Conn) (*tunnelCipher, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second * 10))
+	defer conn.SetReadDeadline(time.Time{})
+	b := make([]byte, tunnelHandshakeSize)
+	if _, err := io.ReadFull(conn, b); err != nil {
+		return nil, fmt.Errorf("failed to read handshake, err: %v", err)
+	}
+	c := TunnelKeys.load(b[:tunnelIDSize])
+	if c == nil {
+		return nil, fmt.Errorf("tunnel key of %s not found", net.IP(b[:tunnelIDSize]))
+	}
+	if c.key.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("tunnel key of %s is expired", c)
+	}
+	nonce := string(b[tunnelIDSize:tunnelHeaderSize])
+	if err := c.openTime(b); err != nil {
+		return nil, fmt.Errorf("invalid handshake, err: %v", err)
+	}
+	if _, ok := seenHandshakes.Get(nonce); ok {
+		return nil, fmt.Errorf("handshake of %s is replayed", c)
+	}
+	seenHandshakes.Add(nonce, struct{}{}, tunnelHandshakeSkew*2)
+	return c, nil
+}
+
+// sealTime seal unix time, it is used as handshake or proof of owning tunnel key
+func (c *tunnelCipher) sealTime(now time.Time) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(now.Unix()))
+	return c.seal(make([]byte, 0, tunnelHandshakeSize), b[:])
+}
+
+// openTime open sealed unix time, it must be fresh
+func (c *tunnelCipher) openTime(b []byte) error {
+	if len(b) != tunnelHandshakeSize {
+		return fmt.Errorf("invalid sealed time from %s", c)
+	}
+	plain, err := c.open(b)
+	if err != nil {
+		return fmt.Errorf("failed to open sealed time from %s, err: %v", c, err)
+	}
+	sent := time.Unix(int64(binary.BigEndian.Uint64(plain)), 0)
+	if d := time.Since(sent); d > tunnelHandshakeSkew || d < -tunnelHandshakeSkew {
+		return fmt.Errorf("sealed time of %s is stale, sent at %s", c, sent)
+	}
+	return nil
+}
+
+// NewTunnelKeyProof client prove it owns tunnel key when renewing or revoking it
+func NewTunnelKeyProof(token string) (string, error) {
+	c, err := parseTunnelKeyToken(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(c.sealTime(time.Now())), nil
+}
+
+// VerifyTunnelKeyProof traffic manager verify proof is sealed by key recently
+func VerifyTunnelKeyProof(key *TunnelKey, proof string) error {
+	c, err := newTunnelCipher(net.ParseIP(key.IPv4), key.Key)
+	if err != nil {
+		return err
+	}
+	b, err := base64.RawURLEncoding.DecodeString(proof)
+	if err != nil {
+		return fmt.Errorf("invalid tunnel key proof")
+	}
+	return c.openTime(b)
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTunnelKey(t *testing.T) {
+	key, err := NewTunnelKey(net.ParseIP("223.254.0.100"), net.ParseIP("efff:ffff:ffff:ffff:ffff:ffff:ffff:9999"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := parseTunnelKeyToken(key.Token())
+	if err != nil {
+		t.Fatal(err)
+	}
+	TunnelKeys.Update(map[string]*TunnelKey{key.IPv4: key})
+	defer TunnelKeys.Update(nil)
+
+	// ipv4 packet 223.254.0.100 -> 223.254.0.1
+	packet := make([]byte, 20)
+	packet[0] = 0x45
+	copy(packet[12:16], []byte{223, 254, 0, 100})
+	copy(packet[16:20], []byte{223, 254, 0, 1})
+	_, plain, err := openTunnelPacket(client.seal(nil, packet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != string(packet) {
+		t.Fatalf("packet changed after open")
+	}
+
+	// source ip is not tun ip of client
+	copy(packet[12:16], []byte{223, 254, 0, 101})
+	if _, _, err = openTunnelPacket(client.seal(nil, packet)); err == nil {
+		t.Fatalf("expect error for spoofed source ip")
+	}
+	copy(packet[12:16], []byte{223, 254, 0, 100})
+
+	// packet from tun device of other replica is sealed by key of destination client
+	reply := make([]byte, 20)
+	reply[0] = 0x45
+	copy(reply[12:16], []byte{10, 96, 0, 10})
+	copy(reply[16:20], []byte{223, 254, 0, 100})
+	if _, _, err = openTunnelPacket(client.seal(nil, reply)); err == nil {
+		t.Fatalf("expect error for packet not from client")
+	}
+	if _, _, srcOwned, err := openPeerPacket(client.seal(nil, reply)); err != nil || srcOwned {
+		t.Fatalf("expect peer packet to client, srcOwned: %v, err: %v", srcOwned, err)
+	}
+	if _, _, srcOwned, err := openPeerPacket(client.seal(nil, packet)); err != nil || !srcOwned {
+		t.Fatalf("expect peer packet from client, srcOwned: %v, err: %v", srcOwned, err)
+	}
+	copy(reply[16:20], []byte{223, 254, 0, 101})
+	if _, _, _, err = openPeerPacket(client.seal(nil, reply)); err == nil {
+		t.Fatalf("expect error for packet not belongs to client")
+	}
+
+	// tampered packet
+	sealed := client.seal(nil, packet)
+	sealed[len(sealed)-1] ^= 0xff
+	if _, _, err = openTunnelPacket(sealed); err == nil {
+		t.Fatalf("expect error for tampered packet")
+	}
+
+	// expired key
+	expireAt := time.Now().Add(-time.Second)
+	key.ExpireAt = &expireAt
+	TunnelKeys.Update(map[string]*TunnelKey{key.IPv4: key})
+	if _, _, err = openTunnelPacket(client.seal(nil, packet)); err == nil {
+		t.Fatalf("expect error for expired key")
+	}
+}
+
+func TestTunnelHandshake(t *testing.T) {
+	key, err := NewTunnelKey(net.ParseIP("223.254.0.100"), nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	TunnelKeys.Update(map[string]*TunnelKey{key.IPv4: key})
+	defer TunnelKeys.Update(nil)
+
+	var handshake = func(write func(conn net.Conn) error) error {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+		go func() { _ = write(client) }()
+		_, err := readTunnelHandshake(server)
+		return err
+	}
+	var sealed []byte
+	if err = handshake(func(conn net.Conn) error {
+		c, _ := parseTunnelKeyToken(key.Token())
+		sealed = c.seal(nil, []byte{0, 0, 0, 0, 0, 0, 0, 0})
+		return writeTunnelHandshake(conn, key.Token())
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// sealed time 0 is stale
+	if err = handshake(func(conn net.Conn) error { _, err := conn.Write(sealed); return err }); err == nil {
+		t.Fatalf("expect error for stale handshake")
+	}
+	// key of unknown client
+	other, _ := NewTunnelKey(net.ParseIP("223.254.0.101"), nil, time.Minute)
+	if err = handshake(func(conn net.Conn) error { return writeTunnelHandshake(conn, other.Token()) }); err == nil {
+		t.Fatalf("expect error for unknown client")
+	}
+	// replayed handshake
+	var record = &recordConn{}
+	_ = writeTunnelHandshake(record, key.Token())
+	for i, expect := range []bool{true, false} {
+		err = handshake(func(conn net.Conn) error { _, err := conn.Write(record.b); return err })
+		if (err == nil) != expect {
+			t.Fatalf("handshake %d: expect accepted %v, but got err: %v", i, expect, err)
+		}
+	}
+}
+
+func TestTunnelKeyProof(t *testing.T) {
+	key, err := NewTunnelKey(net.ParseIP("223.254.0.100"), nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := NewTunnelKeyProof(key.Token())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = VerifyTunnelKeyProof(key, proof); err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewTunnelKey(net.ParseIP("223.254.0.100"), nil, time.Minute)
+	if err = VerifyTunnelKeyProof(other, proof); err == nil {
+		t.Fatalf("expect error for proof sealed by other key")
+	}
+	if err = VerifyTunnelKeyProof(key, "invalid"); err == nil {
+		t.Fatalf("expect error for invalid proof")
+	}
+}
+
+type recordConn struct {
+	net.Conn
+	b []byte
+}
+
+func (c *recordConn) Write(b []byte) (int, error) {
+	c.b = append(c.b, b...)
+	return len(b), nil
+}
+
+func TestRotateTunnelKey(t *testing.T) {
+	old, _ := NewTunnelKey(net.ParseIP("223.254.0.102"), nil, time.Minute)
+	if err := RotateTunnelKey(old.Token()); err == nil {
+		t.Fatalf("expect error for key which is not used by any tunnel")
+	}
+	connector, err := TunnelKeyConnector(UDPOverTCPTunnelConnector(), old.Token())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// old key is expired and removed, traffic manager issues a new one
+	renewed, _ := NewTunnelKey(net.ParseIP("223.254.0.102"), nil, time.Minute)
+	TunnelKeys.Update(map[string]*TunnelKey{renewed.IPv4: renewed})
+	defer TunnelKeys.Update(nil)
+	if err = RotateTunnelKey(renewed.Token()); err != nil {
+		t.Fatal(err)
+	}
+
+	packet := []byte{0x45, 0, 0, 20, 0, 0, 0, 0, 64, 17, 0, 0, 223, 254, 0, 102, 10, 244, 0, 12}
+	sealed := connector.(*tunnelKeyConnector).key.Load().seal(nil, packet)
+	if _, _, err = openTunnelPacket(sealed); err != nil {
+		t.Fatalf("expect tunnel seals packets with new key, but got: %v", err)
+	}
+	// gvisor forwarders still have old token in chain node
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	go func() { _ = writeTunnelHandshake(client, old.Token()) }()
+	if _, err = readTunnelHandshake(server); err != nil {
+		t.Fatalf("expect handshake is sealed by new key, but got: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+type wsTransporter struct {
+	node *Node
+}
+
+// WebSocketTransporter dial traffic manager through ingress by websocket, it goes through HTTPS_PROXY or HTTP_PROXY if set.
+// host of node is kept instead of resolved address, because tls server name and virtual host of ingress need it,
+// eg: -F "wss://kubevpn.example.com/tunnel", -F "ws://kubevpn.example.com:10805?insecure=true"
+func WebSocketTransporter(node *Node) Transporter {
+	return &wsTransporter{node: node}
+}
+
+func (tr *wsTransporter) Dial(ctx context.Context, _ string) (net.Conn, error) {
+	u := &url.URL{Scheme: tr.node.Protocol, Host: tr.node.Addr, Path: "/" + tr.node.Remote}
+	addr := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "wss" {
+			port = "443"
+		}
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+	conn, err := dialProxy(ctx, tr.node, u, addr)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: tr.node.Get("insecure") == "true",
+		})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("tls handshake with %s failed: %v", addr, err)
+		}
+		conn = tlsConn
+	}
+	origin := &url.URL{Scheme: "http", Host: u.Host}
+	if u.Scheme == "wss" {
+		origin.Scheme = "https"
+	}
+	cfg, err := websocket.NewConfig(u.String(), origin.String())
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("websocket handshake with %s failed: %v", u, err)
+	}
+	ws.PayloadType = websocket.BinaryFrame
+	return ws, nil
+}
+
+// WebSocketListener accept websocket of any path, traffic manager is exposed by ingress which terminates tls
+func WebSocketListener(addr string) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	l := &wsListener{
+		Listener: ln,
+		conns:    make(chan *wsConn, 128),
+		closed:   make(chan struct{}),
+	}
+	l.server = &http.Server{
+		Handler: websocket.Server{
+			// client behind proxy may not send origin of traffic manager, accept all
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler:   l.serve,
+		},
+	}
+	go func() {
+		if err := l.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("[ws] serve websocket failed: %v", err)
+		}
+	}()
+	return l, nil
+}
+
+type wsListener struct {
+	net.Listener
+	server *http.Server
+	conns  chan *wsConn
+	once   sync.Once
+	closed chan struct{}
+}
+
+// serve hand over websocket to Accept, websocket is closed once it returns, so wait until handler closes it
+func (l *wsListener) serve(ws *websocket.Conn) {
+	ws.PayloadType = websocket.BinaryFrame
+	c := &wsConn{Conn: ws, path: ws.Request().URL.Path, done: make(chan struct{})}
+	select {
+	case l.conns <- c:
+	case <-l.closed:
+		return
+	}
+	<-c.done
+}
+
+func (l *wsListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *wsListener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return l.server.Close()
+}
+
+type wsConn struct {
+	*websocket.Conn
+	path string
+	once sync.Once
+	done chan struct{}
+}
+
+func (c *wsConn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+	})
+	return c.Conn.Close()
+}
+
+type wsHandler struct {
+	tunnel Handler
+	gtcp   Handler
+	gudp   Handler
+}
+
+// WebSocketHandler dispatch websocket by last element of path, gtcp and gudp for gvisor forwarders, others for tunnel,
+// eg: wss://kubevpn.example.com/tunnel, wss://kubevpn.example.com/tunnel/gtcp, wss://kubevpn.example.com/tunnel/gudp.
+// websocket is exposed by ingress, so it must be served with --authenticate, tunnel only accept sealed packets and
+// gvisor forwarders only accept conn after handshake sealed by tunnel key
+func WebSocketHandler() Handler {
+	return &wsHandler{
+		tunnel: TCPHandler(),
+		gtcp:   GvisorTCPHandler(),
+		gudp:   GvisorUDPHandler(),
+	}
+}
+
+func (h *wsHandler) Handle(ctx context.Context, conn net.Conn) {
+	var p string
+	if c, ok := conn.(*wsConn); ok {
+		p = c.path
+	}
+	log.Debugf("[ws] %s -> %s %s", conn.RemoteAddr(), conn.LocalAddr(), p)
+	// websocket is reachable from outside of cluster, never serve it without tunnel keys
+	if !TunnelKeys.Enabled() {
+		log.Warnf("[ws] reject %s, websocket is only served with --authenticate", conn.RemoteAddr())
+		_ = conn.Close()
+		return
+	}
+	switch path.Base(p) {
+	case "gtcp":
+		h.gtcp.Handle(ctx, conn)
+	case "gudp":
+		h.gudp.Handle(ctx, conn)
+	default:
+		h.tunnel.Handle(ctx, conn)
+	}
+}
+
+// WebSocketForwardAddr address of gvisor forwarder over websocket, eg: wss://kubevpn.example.com/tunnel/gtcp
+func WebSocketForwardAddr(addr string, elem string) string {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return addr
+	}
+	u.Path = path.Join(u.Path, elem)
+	return u.String()
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWebSocketOverHTTPConnect(t *testing.T) {
+	ln, err := WebSocketListener("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if p := conn.(*wsConn).path; p != "/tunnel/gtcp" {
+			t.Errorf("expect path /tunnel/gtcp, but got %s", p)
+			return
+		}
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	// http proxy which only support CONNECT
+	var connected string
+	proxy := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		connected = r.Host
+		remote, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() {
+			_, _ = io.Copy(remote, conn)
+		}()
+		_, _ = io.Copy(conn, remote)
+	})}
+	proxyLn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go proxy.Serve(proxyLn)
+	defer proxy.Close()
+
+	addr := WebSocketForwardAddr("ws://"+ln.Addr().String()+"/tunnel?proxy=http://"+proxyLn.Addr().String(), "gtcp")
+	node, err := ParseNode(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transporter, err := protocols.newTransporter(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	conn, err := transporter.Dial(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if connected != ln.Addr().String() {
+		t.Fatalf("expect CONNECT %s through proxy, but got %q", ln.Addr(), connected)
+	}
+	if _, err = conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 5)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	if _, err = io.ReadFull(conn, b); err != nil || string(b) != "hello" {
+		t.Fatalf("expect echo hello, but got %q, err: %v", b, err)
+	}
+}
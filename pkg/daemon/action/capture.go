@@ -0,0 +1,31 @@
+package action
+
+import (
+	"fmt"
+
+	"github.com/wencaiwulue/kubevpn/pkg/core"
+	"github.com/wencaiwulue/kubevpn/pkg/daemon/rpc"
+)
+
+// Capture stream tunnel packets as pcapng, only sudo daemon has it
+func (svr *Server) Capture(req *rpc.CaptureRequest, resp rpc.Daemon_CaptureServer) error {
+	w := &captureWriter{resp: resp}
+	if !req.Remote {
+		return core.WriteCapture(resp.Context(), w, req.Filter)
+	}
+	if svr.connect == nil {
+		return fmt.Errorf("not connect to any cluster")
+	}
+	return svr.connect.CaptureTrafficManager(resp.Context(), w, req.Filter)
+}
+
+type captureWriter struct {
+	resp rpc.Daemon_CaptureServer
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	if err := w.resp.Send(&rpc.CaptureResponse{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -32,11 +32,23 @@ func (svr *Server) ConnectFork(req *rpc.ConnectRequest, resp rpc.Daemon_ConnectF
 	connect := &handler.ConnectOptions{
 		Namespace:            req.Namespace,
 		Headers:              req.Headers,
+		Weight:               req.Weight,
+		Mirror:               req.Mirror,
+		Fallback:             req.Fallback,
+		SourceLabels:         req.SourceLabels,
+		SourceIPs:            req.SourceIPs,
+		ServerNames:          req.ServerNames,
 		Workloads:            req.Workloads,
 		ExtraCIDR:            req.ExtraCIDR,
 		ExtraDomain:          req.ExtraDomain,
 		UseLocalDNS:          req.UseLocalDNS,
 		Engine:               config.Engine(req.Engine),
+		Streams:              req.Streams,
+		MTU:                  req.MTU,
+		Transport:            config.Transport(req.Transport),
+		TransportAddr:        req.TransportAddr,
+		PrimaryDNS:           req.PrimaryDNS,
+		Replicas:             req.Replicas,
 		OriginKubeconfigPath: req.OriginKubeconfigPath,
 	}
 	var sshConf = util.ParseSshFromRPC(req.SshJump)
@@ -103,11 +115,23 @@ func (svr *Server) redirectConnectForkToSudoDaemon(req *rpc.ConnectRequest, resp
 	connect := &handler.ConnectOptions{
 		Namespace:            req.Namespace,
 		Headers:              req.Headers,
+		Weight:               req.Weight,
+		Mirror:               req.Mirror,
+		Fallback:             req.Fallback,
+		SourceLabels:         req.SourceLabels,
+		SourceIPs:            req.SourceIPs,
+		ServerNames:          req.ServerNames,
 		Workloads:            req.Workloads,
 		ExtraCIDR:            req.ExtraCIDR,
 		ExtraDomain:          req.ExtraDomain,
 		UseLocalDNS:          req.UseLocalDNS,
 		Engine:               config.Engine(req.Engine),
+		Streams:              req.Streams,
+		MTU:                  req.MTU,
+		Transport:            config.Transport(req.Transport),
+		TransportAddr:        req.TransportAddr,
+		PrimaryDNS:           req.PrimaryDNS,
+		Replicas:             req.Replicas,
 		OriginKubeconfigPath: req.OriginKubeconfigPath,
 	}
 	var sshConf = util.ParseSshFromRPC(req.SshJump)
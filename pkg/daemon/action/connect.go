@@ -47,11 +47,23 @@ func (svr *Server) Connect(req *rpc.ConnectRequest, resp rpc.Daemon_ConnectServe
 	svr.connect = &handler.ConnectOptions{
 		Namespace:            req.Namespace,
 		Headers:              req.Headers,
+		Weight:               req.Weight,
+		Mirror:               req.Mirror,
+		Fallback:             req.Fallback,
+		SourceLabels:         req.SourceLabels,
+		SourceIPs:            req.SourceIPs,
+		ServerNames:          req.ServerNames,
 		Workloads:            req.Workloads,
 		ExtraCIDR:            req.ExtraCIDR,
 		ExtraDomain:          req.ExtraDomain,
 		UseLocalDNS:          req.UseLocalDNS,
 		Engine:               config.Engine(req.Engine),
+		Streams:              req.Streams,
+		MTU:                  req.MTU,
+		Transport:            config.Transport(req.Transport),
+		TransportAddr:        req.TransportAddr,
+		PrimaryDNS:           req.PrimaryDNS,
+		Replicas:             req.Replicas,
 		OriginKubeconfigPath: req.OriginKubeconfigPath,
 	}
 	var sshConf = util.ParseSshFromRPC(req.SshJump)
@@ -118,11 +130,23 @@ func (svr *Server) redirectToSudoDaemon(req *rpc.ConnectRequest, resp rpc.Daemon
 	connect := &handler.ConnectOptions{
 		Namespace:            req.Namespace,
 		Headers:              req.Headers,
+		Weight:               req.Weight,
+		Mirror:               req.Mirror,
+		Fallback:             req.Fallback,
+		SourceLabels:         req.SourceLabels,
+		SourceIPs:            req.SourceIPs,
+		ServerNames:          req.ServerNames,
 		Workloads:            req.Workloads,
 		ExtraCIDR:            req.ExtraCIDR,
 		ExtraDomain:          req.ExtraDomain,
 		UseLocalDNS:          req.UseLocalDNS,
 		Engine:               config.Engine(req.Engine),
+		Streams:              req.Streams,
+		MTU:                  req.MTU,
+		Transport:            config.Transport(req.Transport),
+		TransportAddr:        req.TransportAddr,
+		PrimaryDNS:           req.PrimaryDNS,
+		Replicas:             req.Replicas,
 		OriginKubeconfigPath: req.OriginKubeconfigPath,
 	}
 	var sshConf = util.ParseSshFromRPC(req.SshJump)
@@ -0,0 +1,34 @@
+package action
+
+import (
+	"context"
+
+	"github.com/wencaiwulue/kubevpn/pkg/daemon/rpc"
+	"github.com/wencaiwulue/kubevpn/pkg/dns"
+)
+
+// DNSStats counters and cache of dns servers, only sudo daemon has it
+func (svr *Server) DNSStats(ctx context.Context, req *rpc.DNSStatsRequest) (*rpc.DNSStatsResponse, error) {
+	stats := dns.GetStats()
+	var resp = &rpc.DNSStatsResponse{
+		Queries:      stats.Queries,
+		LocalHits:    stats.LocalHits,
+		CacheHits:    stats.CacheHits,
+		NegativeHits: stats.NegativeHits,
+		Forwards:     stats.Forwards,
+		Failures:     stats.Failures,
+		AvgLatency:   int64(stats.AvgLatency),
+		MaxLatency:   int64(stats.MaxLatency),
+	}
+	for _, entry := range stats.Cache {
+		resp.Cache = append(resp.Cache, &rpc.DNSCacheEntry{
+			Name:     entry.Name,
+			Type:     entry.Type,
+			Answer:   entry.Answer,
+			Negative: entry.Negative,
+			TTL:      int64(entry.TTL.Seconds()),
+			Hits:     entry.Hits,
+		})
+	}
+	return resp, nil
+}
@@ -33,11 +33,22 @@ func (svr *Server) Proxy(req *rpc.ConnectRequest, resp rpc.Daemon_ProxyServer) e
 	connect := &handler.ConnectOptions{
 		Namespace:            req.Namespace,
 		Headers:              req.Headers,
+		Weight:               req.Weight,
+		Mirror:               req.Mirror,
+		Fallback:             req.Fallback,
+		SourceLabels:         req.SourceLabels,
+		SourceIPs:            req.SourceIPs,
+		ServerNames:          req.ServerNames,
 		Workloads:            req.Workloads,
 		ExtraCIDR:            req.ExtraCIDR,
 		ExtraDomain:          req.ExtraDomain,
 		UseLocalDNS:          req.UseLocalDNS,
 		Engine:               config.Engine(req.Engine),
+		Streams:              req.Streams,
+		MTU:                  req.MTU,
+		Transport:            config.Transport(req.Transport),
+		TransportAddr:        req.TransportAddr,
+		Replicas:             req.Replicas,
 		OriginKubeconfigPath: req.OriginKubeconfigPath,
 	}
 	var sshConf = util.ParseSshFromRPC(req.SshJump)
@@ -130,6 +141,12 @@ func (svr *Server) Proxy(req *rpc.ConnectRequest, resp rpc.Daemon_ProxyServer) e
 
 	svr.connect.Workloads = req.Workloads
 	svr.connect.Headers = req.Headers
+	svr.connect.Weight = req.Weight
+	svr.connect.Mirror = req.Mirror
+	svr.connect.Fallback = req.Fallback
+	svr.connect.SourceLabels = req.SourceLabels
+	svr.connect.SourceIPs = req.SourceIPs
+	svr.connect.ServerNames = req.ServerNames
 	err = svr.connect.CreateRemoteInboundPod(ctx)
 	if err != nil {
 		log.Errorf("create remote inbound pod failed: %s", err.Error())
@@ -0,0 +1,31 @@
+package action
+
+import (
+	"context"
+
+	"github.com/wencaiwulue/kubevpn/pkg/core"
+	"github.com/wencaiwulue/kubevpn/pkg/daemon/rpc"
+)
+
+// Stats counters of tunnel, only sudo daemon has it
+func (svr *Server) Stats(ctx context.Context, req *rpc.StatsRequest) (*rpc.StatsResponse, error) {
+	var resp = &rpc.StatsResponse{}
+	for _, stat := range core.PeerStats.List() {
+		peer := &rpc.PeerStats{
+			IP:        stat.IP,
+			TxPackets: stat.TxPackets,
+			TxBytes:   stat.TxBytes,
+			RxPackets: stat.RxPackets,
+			RxBytes:   stat.RxBytes,
+			Drops:     stat.Drops,
+			TxRate:    stat.TxRate,
+			RxRate:    stat.RxRate,
+			RTT:       int64(stat.RTT),
+		}
+		if !stat.LastSeen.IsZero() {
+			peer.LastSeen = stat.LastSeen.Unix()
+		}
+		resp.Peers = append(resp.Peers, peer)
+	}
+	return resp, nil
+}
@@ -5,25 +5,53 @@ import (
 	"context"
 	"fmt"
 	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
 
 	"github.com/wencaiwulue/kubevpn/pkg/daemon/rpc"
 )
 
 func (svr *Server) Status(ctx context.Context, request *rpc.StatusRequest) (*rpc.StatusResponse, error) {
+	// tunnel is running in sudo daemon, so get live stats from it
+	var stats = map[string]*rpc.PeerStats{}
+	if cli := svr.GetClient(true); cli != nil {
+		if resp, err := cli.Stats(ctx, &rpc.StatsRequest{}); err == nil {
+			for _, peer := range resp.Peers {
+				stats[peer.IP] = peer
+			}
+		}
+	}
 	var sb = new(bytes.Buffer)
 	w := tabwriter.NewWriter(sb, 1, 1, 1, ' ', 0)
-	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "Mode", "Cluster", "Kubeconfig", "Namespace", "Status")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "Mode", "Cluster", "Kubeconfig", "Namespace", "Status", "TunIP", "Throughput(Tx/Rx)", "RTT")
 	if svr.connect != nil {
 		status := "Connected"
 		cluster := svr.connect.GetKubeconfigCluster()
 		namespace := svr.connect.Namespace
 		kubeconfig := svr.connect.OriginKubeconfigPath
-		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", 0, "full", cluster, kubeconfig, namespace, status)
+		tunIP := svr.connect.GetLocalTunIPv4()
+		throughput, rtt := formatStats(stats[tunIP])
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", 0, "full", cluster, kubeconfig, namespace, status, tunIP, throughput, rtt)
 	}
 
 	for i, options := range svr.secondaryConnect {
-		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, "lite", options.GetKubeconfigCluster(), options.OriginKubeconfigPath, options.Namespace, "Connected")
+		tunIP := options.GetLocalTunIPv4()
+		throughput, rtt := formatStats(stats[tunIP])
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, "lite", options.GetKubeconfigCluster(), options.OriginKubeconfigPath, options.Namespace, "Connected", tunIP, throughput, rtt)
 	}
 	_ = w.Flush()
 	return &rpc.StatusResponse{Message: sb.String()}, nil
 }
+
+func formatStats(peer *rpc.PeerStats) (throughput string, rtt string) {
+	if peer == nil {
+		return "-", "-"
+	}
+	throughput = fmt.Sprintf("%s/s / %s/s", units.HumanSize(float64(peer.TxRate)), units.HumanSize(float64(peer.RxRate)))
+	rtt = "-"
+	if peer.RTT != 0 {
+		rtt = time.Duration(peer.RTT).Round(time.Microsecond).String()
+	}
+	return
+}
@@ -36,6 +36,9 @@ const (
 	Daemon_Get_FullMethodName          = "/rpc.Daemon/Get"
 	Daemon_Upgrade_FullMethodName      = "/rpc.Daemon/Upgrade"
 	Daemon_Status_FullMethodName       = "/rpc.Daemon/Status"
+	Daemon_Stats_FullMethodName        = "/rpc.Daemon/Stats"
+	Daemon_DNSStats_FullMethodName     = "/rpc.Daemon/DNSStats"
+	Daemon_Capture_FullMethodName      = "/rpc.Daemon/Capture"
 	Daemon_Version_FullMethodName      = "/rpc.Daemon/Version"
 	Daemon_Quit_FullMethodName         = "/rpc.Daemon/Quit"
 )
@@ -61,6 +64,9 @@ type DaemonClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error)
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	DNSStats(ctx context.Context, in *DNSStatsRequest, opts ...grpc.CallOption) (*DNSStatsResponse, error)
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (Daemon_CaptureClient, error)
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
 	Quit(ctx context.Context, in *QuitRequest, opts ...grpc.CallOption) (Daemon_QuitClient, error)
 }
@@ -432,6 +438,56 @@ func (c *daemonClient) Status(ctx context.Context, in *StatusRequest, opts ...gr
 	return out, nil
 }
 
+func (c *daemonClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, Daemon_Stats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) DNSStats(ctx context.Context, in *DNSStatsRequest, opts ...grpc.CallOption) (*DNSStatsResponse, error) {
+	out := new(DNSStatsResponse)
+	err := c.cc.Invoke(ctx, Daemon_DNSStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (Daemon_CaptureClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[9], Daemon_Capture_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonCaptureClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Daemon_CaptureClient interface {
+	Recv() (*CaptureResponse, error)
+	grpc.ClientStream
+}
+
+type daemonCaptureClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonCaptureClient) Recv() (*CaptureResponse, error) {
+	m := new(CaptureResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *daemonClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
 	out := new(VersionResponse)
 	err := c.cc.Invoke(ctx, Daemon_Version_FullMethodName, in, out, opts...)
@@ -442,7 +498,7 @@ func (c *daemonClient) Version(ctx context.Context, in *VersionRequest, opts ...
 }
 
 func (c *daemonClient) Quit(ctx context.Context, in *QuitRequest, opts ...grpc.CallOption) (Daemon_QuitClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[9], Daemon_Quit_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[10], Daemon_Quit_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -494,6 +550,9 @@ type DaemonServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error)
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	DNSStats(context.Context, *DNSStatsRequest) (*DNSStatsResponse, error)
+	Capture(*CaptureRequest, Daemon_CaptureServer) error
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	Quit(*QuitRequest, Daemon_QuitServer) error
 	mustEmbedUnimplementedDaemonServer()
@@ -554,6 +613,15 @@ func (UnimplementedDaemonServer) Upgrade(context.Context, *UpgradeRequest) (*Upg
 func (UnimplementedDaemonServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedDaemonServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedDaemonServer) DNSStats(context.Context, *DNSStatsRequest) (*DNSStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DNSStats not implemented")
+}
+func (UnimplementedDaemonServer) Capture(*CaptureRequest, Daemon_CaptureServer) error {
+	return status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
 func (UnimplementedDaemonServer) Version(context.Context, *VersionRequest) (*VersionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
 }
@@ -911,6 +979,63 @@ func _Daemon_Status_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_DNSStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DNSStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).DNSStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_DNSStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).DNSStats(ctx, req.(*DNSStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_Capture_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CaptureRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServer).Capture(m, &daemonCaptureServer{stream})
+}
+
+type Daemon_CaptureServer interface {
+	Send(*CaptureResponse) error
+	grpc.ServerStream
+}
+
+type daemonCaptureServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonCaptureServer) Send(m *CaptureResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Daemon_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VersionRequest)
 	if err := dec(in); err != nil {
@@ -989,6 +1114,14 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Daemon_Status_Handler,
 		},
+		{
+			MethodName: "Stats",
+			Handler:    _Daemon_Stats_Handler,
+		},
+		{
+			MethodName: "DNSStats",
+			Handler:    _Daemon_DNSStats_Handler,
+		},
 		{
 			MethodName: "Version",
 			Handler:    _Daemon_Version_Handler,
@@ -1041,6 +1174,11 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Daemon_Logs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Capture",
+			Handler:       _Daemon_Capture_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Quit",
 			Handler:       _Daemon_Quit_Handler,
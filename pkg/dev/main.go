@@ -70,6 +70,7 @@ type Options struct {
 	ExtraDomain   []string
 	ConnectMode   ConnectMode
 	Engine        config.Engine
+	MTU           int32
 
 	// docker options
 	DockerImage string
@@ -464,6 +465,7 @@ func (d *Options) doConnect(ctx context.Context, f cmdutil.Factory, conf *util.S
 		ExtraCIDR:            d.ExtraCIDR,
 		ExtraDomain:          d.ExtraDomain,
 		Engine:               d.Engine,
+		MTU:                  d.MTU,
 		OriginKubeconfigPath: util.GetKubeconfigPath(f),
 	}
 	if err = connect.InitClient(f); err != nil {
@@ -513,6 +515,7 @@ func (d *Options) doConnect(ctx context.Context, f cmdutil.Factory, conf *util.S
 			ExtraDomain:          connect.ExtraDomain,
 			UseLocalDNS:          connect.UseLocalDNS,
 			Engine:               string(connect.Engine),
+			MTU:                  connect.MTU,
 			OriginKubeconfigPath: util.GetKubeconfigPath(f),
 			TransferImage:        transferImage,
 			Image:                config.Image,
@@ -642,6 +645,10 @@ func createConnectContainer(noProxy bool, connect handler.ConnectOptions, path s
 		}
 	}
 
+	if connect.MTU > 0 {
+		entrypoint = append(entrypoint, "--mtu", strconv.Itoa(int(connect.MTU)))
+	}
+
 	runConfig := &container.Config{
 		User:            "root",
 		AttachStdin:     false,
@@ -0,0 +1,198 @@
+package dns
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// maxTTL answer is cached at most maxTTL even if ttl of record is longer, service may be changed
+	maxTTL = time.Minute * 5
+	// negativeTTL not found is cached for it if dns not tells soa minimum
+	negativeTTL = time.Second * 5
+	// maxNegativeTTL service may be created soon, not found is cached at most it
+	maxNegativeTTL = time.Second * 30
+)
+
+// answers cache of dns forward servers, it is shared by servers of all clusters, key contains dns servers of cluster
+var answers = cache.NewLRUExpireCache(1000)
+
+// dnsStats counters of dns servers in daemon
+var dnsStats = &queryStats{}
+
+// cachedAnswer answer of query, msg is nil if not found
+type cachedAnswer struct {
+	origin string // eg: productpage.
+	name   string // name which is found, eg: productpage.default.svc.cluster.local.
+	qtype  uint16
+	msg    *miekgdns.Msg
+	expire time.Time
+	hits   atomic.Uint64
+}
+
+func cacheKey(servers []string, q miekgdns.Question) string {
+	return strings.Join(servers, ",") + "/" + miekgdns.TypeToString[q.Qtype] + "/" + strings.ToLower(q.Name)
+}
+
+func addAnswer(key string, a *cachedAnswer, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	a.expire = time.Now().Add(ttl)
+	answers.Add(key, a, ttl)
+}
+
+func getAnswer(key string) (*cachedAnswer, bool) {
+	v, ok := answers.Get(key)
+	if !ok {
+		return nil, false
+	}
+	a := v.(*cachedAnswer)
+	a.hits.Add(1)
+	return a, true
+}
+
+// reply answer of cache, ttl of records is time left
+func (a *cachedAnswer) reply(r *miekgdns.Msg) *miekgdns.Msg {
+	msg := a.msg.Copy()
+	msg.Id = r.Id
+	msg.Question = r.Question
+	left := uint32(time.Until(a.expire).Seconds())
+	for _, rr := range msg.Answer {
+		if rr.Header().Ttl > left {
+			rr.Header().Ttl = left
+		}
+	}
+	return msg
+}
+
+// answerTTL minimum ttl of records, at most maxTTL
+func answerTTL(msg *miekgdns.Msg) time.Duration {
+	ttl := maxTTL
+	for _, rr := range msg.Answer {
+		if t := time.Duration(rr.Header().Ttl) * time.Second; t < ttl {
+			ttl = t
+		}
+	}
+	return ttl
+}
+
+// negativeAnswerTTL minimum of soa and it's minimum ttl in authority section, rfc2308
+func negativeAnswerTTL(msg *miekgdns.Msg) (time.Duration, bool) {
+	for _, rr := range msg.Ns {
+		if soa, ok := rr.(*miekgdns.SOA); ok {
+			ttl := soa.Minttl
+			if soa.Hdr.Ttl < ttl {
+				ttl = soa.Hdr.Ttl
+			}
+			if t := time.Duration(ttl) * time.Second; t < maxNegativeTTL {
+				return t, true
+			}
+			return maxNegativeTTL, true
+		}
+	}
+	return 0, false
+}
+
+// InvalidateService remove answers and not found of service, it is called once service is created, updated or deleted
+func InvalidateService(name, namespace string) {
+	prefix := strings.ToLower(name + "." + namespace + ".")
+	for _, key := range answers.Keys() {
+		v, ok := answers.Get(key)
+		if !ok {
+			continue
+		}
+		a := v.(*cachedAnswer)
+		labels := miekgdns.SplitDomainName(strings.ToLower(a.origin))
+		if strings.HasPrefix(a.name, prefix) || (len(labels) != 0 && labels[0] == strings.ToLower(name)) {
+			answers.Remove(key)
+		}
+	}
+}
+
+type queryStats struct {
+	queries      atomic.Uint64
+	localHits    atomic.Uint64
+	hits         atomic.Uint64
+	negativeHits atomic.Uint64
+	forwards     atomic.Uint64
+	failures     atomic.Uint64
+	latency      atomic.Int64
+	maxLatency   atomic.Int64
+}
+
+// observe latency of query which is forwarded to dns
+func (s *queryStats) observe(d time.Duration, ok bool) {
+	s.forwards.Add(1)
+	if !ok {
+		s.failures.Add(1)
+	}
+	s.latency.Add(int64(d))
+	for {
+		max := s.maxLatency.Load()
+		if int64(d) <= max || s.maxLatency.CompareAndSwap(max, int64(d)) {
+			return
+		}
+	}
+}
+
+// Stats counters of dns servers and answers in cache
+type Stats struct {
+	Queries      uint64
+	LocalHits    uint64
+	CacheHits    uint64
+	NegativeHits uint64
+	Forwards     uint64
+	Failures     uint64
+	AvgLatency   time.Duration
+	MaxLatency   time.Duration
+	Cache        []CacheEntry
+}
+
+type CacheEntry struct {
+	Name     string
+	Type     string
+	Answer   string
+	Negative bool
+	TTL      time.Duration
+	Hits     uint64
+}
+
+func GetStats() Stats {
+	stats := Stats{
+		Queries:      dnsStats.queries.Load(),
+		LocalHits:    dnsStats.localHits.Load(),
+		CacheHits:    dnsStats.hits.Load(),
+		NegativeHits: dnsStats.negativeHits.Load(),
+		Forwards:     dnsStats.forwards.Load(),
+		Failures:     dnsStats.failures.Load(),
+		MaxLatency:   time.Duration(dnsStats.maxLatency.Load()),
+	}
+	if stats.Forwards != 0 {
+		stats.AvgLatency = time.Duration(dnsStats.latency.Load() / int64(stats.Forwards))
+	}
+	for _, key := range answers.Keys() {
+		v, ok := answers.Get(key)
+		if !ok {
+			continue
+		}
+		a := v.(*cachedAnswer)
+		stats.Cache = append(stats.Cache, CacheEntry{
+			Name:     a.origin,
+			Type:     miekgdns.TypeToString[a.qtype],
+			Answer:   a.name,
+			Negative: a.msg == nil || len(a.msg.Answer) == 0,
+			TTL:      time.Until(a.expire).Round(time.Second),
+			Hits:     a.hits.Load(),
+		})
+	}
+	sort.SliceStable(stats.Cache, func(i, j int) bool {
+		return stats.Cache[i].Name < stats.Cache[j].Name
+	})
+	return stats
+}
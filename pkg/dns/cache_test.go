@@ -0,0 +1,93 @@
+package dns
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+func TestServerCache(t *testing.T) {
+	var count atomic.Int64
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster := &miekgdns.Server{PacketConn: pc, Handler: miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+		count.Add(1)
+		msg := new(miekgdns.Msg)
+		msg.SetReply(r)
+		if r.Question[0].Name == "productpage.default.svc.cluster.local." {
+			msg.Answer = append(msg.Answer, &miekgdns.A{
+				Hdr: miekgdns.RR_Header{Name: r.Question[0].Name, Rrtype: miekgdns.TypeA, Class: miekgdns.ClassINET, Ttl: 60},
+				A:   net.ParseIP("10.0.0.1"),
+			})
+		} else {
+			msg.Rcode = miekgdns.RcodeNameError
+			msg.Ns = append(msg.Ns, &miekgdns.SOA{
+				Hdr:    miekgdns.RR_Header{Name: "cluster.local.", Rrtype: miekgdns.TypeSOA, Class: miekgdns.ClassINET, Ttl: 30},
+				Ns:     "ns.dns.cluster.local.",
+				Mbox:   "hostmaster.cluster.local.",
+				Minttl: 10,
+			})
+		}
+		_ = w.WriteMsg(msg)
+	})}
+	go cluster.ActivateAndServe()
+	defer cluster.Shutdown()
+
+	s := newServer(clientConfig(pc.LocalAddr().(*net.UDPAddr), "default"), nil)
+	ln, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &miekgdns.Server{PacketConn: ln, Handler: s}
+	go srv.ActivateAndServe()
+	defer srv.Shutdown()
+
+	query := func(name string) *miekgdns.Msg {
+		msg := new(miekgdns.Msg)
+		msg.SetQuestion(name, miekgdns.TypeA)
+		answer, _, err := (&miekgdns.Client{Net: "udp"}).Exchange(msg, ln.LocalAddr().String())
+		if err != nil {
+			t.Fatalf("query %s failed: %v", name, err)
+		}
+		return answer
+	}
+
+	hits := GetStats().CacheHits
+	query("productpage.")
+	n := count.Load()
+	answer := query("productpage.")
+	if count.Load() != n || GetStats().CacheHits != hits+1 {
+		t.Fatalf("expect answer from cache, but forwarded to cluster dns")
+	}
+	if len(answer.Answer) != 1 || answer.Answer[0].Header().Name != "productpage." || answer.Answer[0].Header().Ttl > 60 {
+		t.Fatalf("unexpected answer from cache: %v", answer.Answer)
+	}
+
+	negativeHits := GetStats().NegativeHits
+	query("reviews.")
+	n = count.Load()
+	if answer = query("reviews."); len(answer.Answer) != 0 {
+		t.Fatalf("expect not found, but got %v", answer.Answer)
+	}
+	if count.Load() != n || GetStats().NegativeHits != negativeHits+1 {
+		t.Fatalf("expect not found from cache, but forwarded to cluster dns")
+	}
+	key := cacheKey(s.forwardDNS.Servers, miekgdns.Question{Name: "reviews.", Qtype: miekgdns.TypeA})
+	// soa minimum of cluster dns is 10s
+	if a, ok := getAnswer(key); !ok || a.msg != nil || time.Until(a.expire) > time.Second*10 {
+		t.Fatalf("expect not found is cached by soa minimum")
+	}
+
+	// service reviews is created
+	InvalidateService("reviews", "default")
+	n = count.Load()
+	query("reviews.")
+	if count.Load() == n {
+		t.Fatalf("expect forwarded to cluster dns after invalidated, but answered from cache")
+	}
+}
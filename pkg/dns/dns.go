@@ -30,9 +30,19 @@ type Config struct {
 	UseLocalDNS bool
 	TunName     string
 	// lite mode means connect to another cluster
-	Lite bool
+	Lite        bool
+	ExtraDomain []string
+	// kubeconfig context, names qualified by it are resolved by this cluster, eg: productpage.default.svc.<context>
+	Context string
+	// primary cluster resolves unqualified names, eg: productpage, productpage.default
+	Primary bool
 
 	Hosts []Entry
+
+	// revert dns which is set by split dns server
+	revert func()
+	// zone local zone of cluster, it is set by WatchLocalZone
+	zone *localZone
 }
 
 func (c *Config) AddServiceNameToHosts(ctx context.Context, serviceInterface v13.ServiceInterface, hosts ...Entry) {
@@ -76,6 +86,10 @@ func (c *Config) AddServiceNameToHosts(ctx context.Context, serviceInterface v13
 							if watch.Error == event.Type || watch.Bookmark == event.Type {
 								continue
 							}
+							// cached answer or not found of service is stale
+							if svc, ok := event.Object.(*v12.Service); ok {
+								InvalidateService(svc.Name, svc.Namespace)
+							}
 							if !rateLimiter.TryAccept() {
 								return
 							}
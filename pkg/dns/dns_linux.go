@@ -5,6 +5,7 @@ package dns
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,14 +14,103 @@ import (
 	"github.com/docker/docker/libnetwork/resolvconf"
 	miekgdns "github.com/miekg/dns"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/coredns/caddy"
 	_ "github.com/coredns/coredns/core/dnsserver"
 	_ "github.com/coredns/coredns/core/plugin"
 )
 
+// resolverIP split-horizon dns server listen on it with port 53 if possible, NetworkManager only support port 53
+const resolverIP = "127.0.0.153"
+
+// SetupDNS run split-horizon dns server in daemon, and install it to tun by systemd-resolved or NetworkManager,
+// fallback to rewrite /etc/resolv.conf if both of them are not available
 // systemd-resolve --status, systemd-resolve --flush-caches
 func (c *Config) SetupDNS() error {
+	if !c.UseLocalDNS {
+		err := c.setupSplitDNS()
+		if err == nil {
+			return nil
+		}
+		log.Warnf("failed to setup split dns: %v, fallback to rewrite resolv.conf", err)
+	}
+	return c.setupResolvConf()
+}
+
+func (c *Config) setupSplitDNS() error {
+	addr, err := splitDNS.serve(listenResolver, upstreamOf)
+	if err != nil {
+		return err
+	}
+	zone := newClusterZone(c.Context, c.Primary, c.Config, c.Ns, c.ExtraDomain, c.zone)
+	splitDNS.add(zone)
+
+	// only primary cluster takes short names, others take names qualified by context. search domains are cluster zones,
+	// they also route queries, short names like productpage.default are expanded by them. namespaces are not routed,
+	// names like www.google.dev must not be taken over just because namespace dev exists
+	var search, routing []string
+	if c.Primary {
+		search = clusterSearch(c.Config.Search)
+	}
+	// only svc.<context> is routed to split dns server, other names under context are resolved as before
+	if zone.qualifier != "" {
+		routing = append(routing, strings.TrimSuffix(zone.qualifier, "."))
+	}
+	routing = append(routing, c.ExtraDomain...)
+	revert, err := setLinkDNS(c.TunName, addr, search, routing)
+	if err != nil {
+		log.Debugf("failed to set dns by systemd-resolved: %v, try NetworkManager", err)
+		revert, err = setDeviceDNS(c.TunName, addr, search, routing)
+	}
+	if err != nil {
+		splitDNS.remove(zone)
+		return err
+	}
+	c.revert = func() {
+		revert()
+		splitDNS.remove(zone)
+	}
+	return nil
+}
+
+func listenResolver() (net.PacketConn, error) {
+	pc, err := net.ListenPacket("udp", net.JoinHostPort(resolverIP, "53"))
+	if err != nil {
+		pc, err = net.ListenPacket("udp", "127.0.0.1:0")
+	}
+	return pc, err
+}
+
+// resolvedStubs stub listeners of systemd-resolved, queries forwarded to them are routed back to split dns server
+var resolvedStubs = sets.New[string]("127.0.0.53", "127.0.0.54")
+
+// upstreamOf nameservers in /etc/resolv.conf before split dns server is installed, except stub of systemd-resolved
+func upstreamOf(addr *net.UDPAddr) (upstream []string) {
+	conf, err := readResolvConf()
+	if err != nil {
+		return
+	}
+	for _, s := range conf.Servers {
+		if resolvedStubs.Has(s) {
+			continue
+		}
+		if hostPort := net.JoinHostPort(s, conf.Port); hostPort != addr.String() {
+			upstream = append(upstream, hostPort)
+		}
+	}
+	return
+}
+
+func readResolvConf() (*miekgdns.ClientConfig, error) {
+	readFile, err := os.ReadFile(filepath.Join("/", "etc", "resolv.conf"))
+	if err != nil {
+		return nil, err
+	}
+	return miekgdns.ClientConfigFromReader(bytes.NewBufferString(string(readFile)))
+}
+
+func (c *Config) setupResolvConf() error {
 	clientConfig := c.Config
 	useLocalDNS := c.UseLocalDNS
 	tunName := c.TunName
@@ -28,16 +118,13 @@ func (c *Config) SetupDNS() error {
 	existNameservers := make([]string, 0)
 	existSearches := make([]string, 0)
 	filename := filepath.Join("/", "etc", "resolv.conf")
-	readFile, err := os.ReadFile(filename)
+	resolvConf, err := readResolvConf()
 	if err == nil {
-		resolvConf, err := miekgdns.ClientConfigFromReader(bytes.NewBufferString(string(readFile)))
-		if err == nil {
-			if len(resolvConf.Servers) != 0 {
-				existNameservers = append(existNameservers, resolvConf.Servers...)
-			}
-			if len(resolvConf.Search) != 0 {
-				existSearches = append(existSearches, resolvConf.Search...)
-			}
+		if len(resolvConf.Servers) != 0 {
+			existNameservers = append(existNameservers, resolvConf.Servers...)
+		}
+		if len(resolvConf.Search) != 0 {
+			existSearches = append(existSearches, resolvConf.Search...)
 		}
 	}
 
@@ -113,6 +200,10 @@ func SetupLocalDNS(clientConfig *miekgdns.ClientConfig, existNameservers []strin
 func (c *Config) CancelDNS() {
 	c.updateHosts("")
 
+	if c.revert != nil {
+		c.revert()
+		return
+	}
 	if !c.Lite {
 		filename := filepath.Join("/", "etc", "resolv.conf")
 		_ = os.Rename(getBackupFilename(filename), filename)
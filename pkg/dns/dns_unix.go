@@ -56,6 +56,12 @@ func (c *Config) usingResolver() {
 		Ndots:   5,
 		Timeout: 2,
 	}
+	// for support like: service.namespace.svc.<context>:port
+	c.usingSplitDNS()
+	// only primary cluster takes short names
+	if !c.Primary {
+		return
+	}
 	// for support like: service:port, service.namespace.svc.cluster.local:port
 	if !c.Lite {
 		filename := filepath.Join("/", "etc", "resolver", "local")
@@ -70,7 +76,7 @@ func (c *Config) usingResolver() {
 	}
 	go func(port int, clientConfig *miekgdns.ClientConfig) {
 		for {
-			log.Errorln(NewDNSServer("udp", "127.0.0.1:"+strconv.Itoa(port), clientConfig))
+			log.Errorln(miekgdns.ListenAndServe("127.0.0.1:"+strconv.Itoa(port), "udp", newServer(clientConfig, c.zone)))
 		}
 	}(port, clientConfig)
 	config = miekgdns.ClientConfig{
@@ -113,6 +119,39 @@ func (c *Config) usingResolver() {
 	}
 }
 
+// usingSplitDNS resolve names qualified by kubeconfig context by split dns server, it is shared by clusters
+func (c *Config) usingSplitDNS() {
+	zone := newClusterZone(c.Context, c.Primary, c.Config, c.Ns, c.ExtraDomain, c.zone)
+	if zone.qualifier == "" {
+		return
+	}
+	listen := func() (net.PacketConn, error) {
+		return net.ListenPacket("udp", "127.0.0.1:0")
+	}
+	// only qualified names are routed to it by resolver
+	addr, err := splitDNS.serve(listen, func(*net.UDPAddr) []string { return nil })
+	if err != nil {
+		log.Errorf("start split dns server error: %v", err)
+		return
+	}
+	splitDNS.add(zone)
+	// resolver of svc.<context>, eg: /etc/resolver/svc.kind-kind, other names under context are resolved as before
+	filename := filepath.Join("/", "etc", "resolver", strings.TrimSuffix(zone.qualifier, "."))
+	config := miekgdns.ClientConfig{
+		Servers: []string{addr.IP.String()},
+		Port:    strconv.Itoa(addr.Port),
+		Ndots:   5,
+		Timeout: 2,
+	}
+	if err = os.WriteFile(filename, []byte(toString(config)), 0644); err != nil {
+		log.Errorf("Failed to write resovler %s error: %v", filename, err)
+	}
+	c.revert = func() {
+		_ = os.Remove(filename)
+		splitDNS.remove(zone)
+	}
+}
+
 func (c *Config) usingNetworkSetup(ip string, namespace string) {
 	networkSetup(ip, namespace)
 	var ctx context.Context
@@ -190,6 +229,9 @@ func (c *Config) CancelDNS() {
 	if cancel != nil {
 		cancel()
 	}
+	if c.revert != nil {
+		c.revert()
+	}
 	if !c.Lite {
 		_ = os.RemoveAll(filepath.Join("/", "etc", "resolver"))
 	}
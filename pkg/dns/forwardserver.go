@@ -17,7 +17,6 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/semaphore"
 	"golang.org/x/time/rate"
-	"k8s.io/apimachinery/pkg/util/cache"
 )
 
 var (
@@ -27,25 +26,31 @@ var (
 
 // github.com/docker/docker@v23.0.1+incompatible/libnetwork/network_windows.go:53
 type server struct {
-	dnsCache   *cache.LRUExpireCache
 	forwardDNS *miekgdns.ClientConfig
 	client     *miekgdns.Client
+	// zone answers services, endpoints and pods of cluster locally, it may be nil
+	zone *localZone
 
 	fwdSem      *semaphore.Weighted // Limit the number of concurrent external DNS requests in-flight
 	logInverval rate.Sometimes      // Rate-limit logging about hitting the fwdSem limit
 }
 
 func NewDNSServer(network, address string, forwardDNS *miekgdns.ClientConfig) error {
-	return miekgdns.ListenAndServe(address, network, &server{
-		dnsCache:    cache.NewLRUExpireCache(1000),
+	return miekgdns.ListenAndServe(address, network, newServer(forwardDNS, nil))
+}
+
+func newServer(forwardDNS *miekgdns.ClientConfig, zone *localZone) *server {
+	return &server{
 		forwardDNS:  forwardDNS,
+		zone:        zone,
 		client:      &miekgdns.Client{Net: "udp", SingleInflight: true, Timeout: time.Second * 30},
 		fwdSem:      semaphore.NewWeighted(maxConcurrent),
 		logInverval: rate.Sometimes{Interval: logInterval},
-	})
+	}
 }
 
-// ServeDNS consider using a cache
+// ServeDNS answer from local zone if name is in it, or from cache if it's not expired, otherwise try every search suffix on every dns server,
+// the first answer wins, answer is cached by ttl of records, and not found is cached by soa minimum
 // eg: nslookup -port=56571 code.byted.org 127.0.0.1
 func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 	defer w.Close()
@@ -55,6 +60,29 @@ func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 		return
 	}
 
+	dnsStats.queries.Add(1)
+	var q = r.Question[0]
+	var originName = q.Name
+	searchList := fix(originName, s.forwardDNS.Search)
+	for _, name := range searchList {
+		if answer, ok := s.local(r, name); ok {
+			_ = w.WriteMsg(answer)
+			return
+		}
+	}
+	key := cacheKey(s.forwardDNS.Servers, q)
+	if a, ok := getAnswer(key); ok {
+		if a.msg == nil {
+			dnsStats.negativeHits.Add(1)
+			r.Response = true
+			_ = w.WriteMsg(r)
+			return
+		}
+		dnsStats.hits.Add(1)
+		_ = w.WriteMsg(a.reply(r))
+		return
+	}
+
 	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancelFunc()
 	// limits the number of outstanding concurrent queries
@@ -69,16 +97,17 @@ func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 	defer s.fwdSem.Release(1)
 
 	var wg = &sync.WaitGroup{}
+	var start = time.Now()
 
 	var done = &atomic.Value{}
 	done.Store(false)
-	var q = r.Question[0]
-	var originName = q.Name
+	// not found is cached only if all dns servers replied
+	var completed atomic.Bool
+	var lock sync.Mutex
+	var failed bool
+	var notFoundTTL = maxNegativeTTL
 
-	searchList := fix(originName, s.forwardDNS.Search)
-	if v, ok := s.dnsCache.Get(originName); ok {
-		searchList = []string{v.(string)}
-	}
+	marshal, _ := json.Marshal(r)
 
 	for _, name := range searchList {
 		// only should have dot [5,6]
@@ -94,7 +123,6 @@ func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 			go func(name, dnsAddr string) {
 				defer wg.Done()
 				var msg miekgdns.Msg
-				marshal, _ := json.Marshal(r)
 				_ = json.Unmarshal(marshal, &msg)
 				for i := 0; i < len(msg.Question); i++ {
 					msg.Question[i].Name = name
@@ -105,15 +133,20 @@ func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 				answer, _, err := s.client.ExchangeContext(context.Background(), &msg, net.JoinHostPort(dnsAddr, s.forwardDNS.Port))
 
 				if err == nil && len(answer.Answer) != 0 {
-					s.dnsCache.Add(originName, name, time.Hour*24*365*100) // never expire
-
 					for i := 0; i < len(answer.Answer); i++ {
 						answer.Answer[i].Header().Name = originName
 					}
 					for i := 0; i < len(answer.Question); i++ {
 						answer.Question[i].Name = originName
 					}
+					addAnswer(key, &cachedAnswer{origin: originName, name: name, qtype: q.Qtype, msg: answer.Copy()}, answerTTL(answer))
 
+					// only the first answer is written
+					lock.Lock()
+					defer lock.Unlock()
+					if done.Load().(bool) {
+						return
+					}
 					r.Answer = answer.Answer
 					r.Response = answer.Response
 					r.Authoritative = answer.Authoritative
@@ -136,6 +169,15 @@ func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 						return
 					}
 				}
+				lock.Lock()
+				if err != nil {
+					failed = true
+				} else if ttl, ok := negativeAnswerTTL(answer); ok && ttl < notFoundTTL {
+					notFoundTTL = ttl
+				} else if !ok && negativeTTL < notFoundTTL {
+					notFoundTTL = negativeTTL
+				}
+				lock.Unlock()
 				if err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
 					log.Debugf(err.Error())
 				}
@@ -144,18 +186,63 @@ func (s *server) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
 	}
 	go func() {
 		wg.Wait()
+		completed.Store(true)
 		cancelFunc()
 	}()
 
 	select {
 	case <-ctx.Done():
 	}
-	if !done.Load().(bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	found := done.Load().(bool)
+	dnsStats.observe(time.Since(start), found)
+	if !found {
+		// answers come after timeout are dropped
+		done.Store(true)
+		if completed.Load() && !failed {
+			addAnswer(key, &cachedAnswer{origin: originName, qtype: q.Qtype}, notFoundTTL)
+		}
 		r.Response = true
 		_ = w.WriteMsg(r)
 	}
 }
 
+// local answer query of name by local zone, owner of records is name of question, target of external name service is
+// resolved by cluster dns, like kubernetes plugin of coredns does
+func (s *server) local(r *miekgdns.Msg, name string) (*miekgdns.Msg, bool) {
+	q := r.Question[0]
+	rrs, ok := s.zone.lookup(strings.ToLower(name), q.Qtype)
+	if !ok {
+		return nil, false
+	}
+	dnsStats.localHits.Add(1)
+	msg := new(miekgdns.Msg)
+	msg.SetReply(r)
+	msg.Authoritative = true
+	msg.RecursionAvailable = true
+	for _, rr := range rrs {
+		if strings.EqualFold(rr.Header().Name, name) {
+			rr.Header().Name = q.Name
+		}
+		msg.Answer = append(msg.Answer, rr)
+	}
+	if len(rrs) == 1 && q.Qtype != miekgdns.TypeCNAME {
+		if cname, ok := rrs[0].(*miekgdns.CNAME); ok {
+			query := new(miekgdns.Msg)
+			query.SetQuestion(cname.Target, q.Qtype)
+			for _, dnsAddr := range s.forwardDNS.Servers {
+				answer, _, err := s.client.Exchange(query, net.JoinHostPort(dnsAddr, s.forwardDNS.Port))
+				if err == nil {
+					msg.Answer = append(msg.Answer, answer.Answer...)
+					break
+				}
+			}
+		}
+	}
+	return msg, true
+}
+
 func fix(domain string, suffix []string) (result []string) {
 	result = []string{domain}
 	for _, s := range suffix {
@@ -0,0 +1,287 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// localTTL ttl of records in local zone, same as kubernetes plugin of coredns
+const localTTL = 5
+
+const podIPIndex = "ip"
+
+// localZone authoritative zone of cluster which is built from informers of services, endpointslices and pods,
+// names in it are answered locally, so they keep working even if cluster dns is slow, like:
+// productpage.default.svc.cluster.local, mongo-0.mongo-headless.db.svc.cluster.local,
+// _mongo._tcp.mongo-headless.db.svc.cluster.local (SRV), 10-244-0-12.default.pod.cluster.local
+type localZone struct {
+	domain   string // eg: cluster.local.
+	services corelisters.ServiceLister
+	slices   discoverylisters.EndpointSliceLister // nil if has no permission
+	pods     toolscache.Indexer                   // nil if has no permission
+	synced   atomic.Bool
+}
+
+// WatchLocalZone list and watch services, endpointslices and pods of all namespaces, or namespace if has no permission,
+// names not in local zone are still forwarded to cluster dns
+func (c *Config) WatchLocalZone(ctx context.Context, clientset kubernetes.Interface, namespace string) {
+	scope := metav1.NamespaceAll
+	if _, err := clientset.CoreV1().Services(scope).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		log.Debugf("can not list services of all namespaces, only watch namespace %s: %v", namespace, err)
+		scope = namespace
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, time.Minute*5, informers.WithNamespace(scope))
+	z := &localZone{
+		domain:   "cluster.local.",
+		services: factory.Core().V1().Services().Lister(),
+	}
+	for _, search := range clusterSearch(c.Config.Search) {
+		if strings.HasPrefix(search, "svc.") {
+			z.domain = miekgdns.Fqdn(strings.ToLower(strings.TrimPrefix(search, "svc.")))
+		}
+	}
+	if _, err := clientset.DiscoveryV1().EndpointSlices(scope).List(ctx, metav1.ListOptions{Limit: 1}); err == nil {
+		z.slices = factory.Discovery().V1().EndpointSlices().Lister()
+	} else {
+		log.Debugf("can not list endpointslices, headless services are resolved by cluster dns: %v", err)
+	}
+	if _, err := clientset.CoreV1().Pods(scope).List(ctx, metav1.ListOptions{Limit: 1}); err == nil {
+		informer := factory.Core().V1().Pods().Informer()
+		// only keep ip of pod, pods of cluster may be too many
+		_ = informer.SetTransform(func(obj interface{}) (interface{}, error) {
+			if pod, ok := obj.(*v1.Pod); ok {
+				return &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace, ResourceVersion: pod.ResourceVersion},
+					Status:     v1.PodStatus{PodIP: pod.Status.PodIP, PodIPs: pod.Status.PodIPs},
+				}, nil
+			}
+			return obj, nil
+		})
+		_ = informer.AddIndexers(toolscache.Indexers{podIPIndex: func(obj interface{}) ([]string, error) {
+			var ips []string
+			if pod, ok := obj.(*v1.Pod); ok {
+				for _, ip := range pod.Status.PodIPs {
+					ips = append(ips, ip.IP)
+				}
+			}
+			return ips, nil
+		}})
+		z.pods = informer.GetIndexer()
+	} else {
+		log.Debugf("can not list pods, pod names are resolved by cluster dns: %v", err)
+	}
+	c.zone = z
+	factory.Start(ctx.Done())
+	go func() {
+		for _, synced := range factory.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				return
+			}
+		}
+		z.synced.Store(true)
+		log.Debugf("local dns zone %s is synced", z.domain)
+	}()
+}
+
+// lookup records of name, ok is false if name is not in local zone, it should be forwarded to cluster dns
+func (z *localZone) lookup(name string, qtype uint16) (rrs []miekgdns.RR, ok bool) {
+	if z == nil || !z.synced.Load() || !miekgdns.IsSubDomain(z.domain, name) {
+		return nil, false
+	}
+	parts := miekgdns.SplitDomainName(strings.TrimSuffix(name, z.domain))
+	if len(parts) < 3 {
+		return nil, false
+	}
+	switch kind := parts[len(parts)-1]; {
+	case kind == "svc" && len(parts) == 3:
+		return z.service(name, parts[1], parts[0], qtype)
+	case kind == "svc" && len(parts) == 4:
+		return z.endpoint(name, parts[2], parts[1], parts[0], qtype)
+	case kind == "svc" && len(parts) == 5 && strings.HasPrefix(parts[0], "_") && strings.HasPrefix(parts[1], "_"):
+		return z.srv(name, parts[3], parts[2], parts[0][1:], parts[1][1:], qtype)
+	case kind == "pod" && len(parts) == 3:
+		return z.pod(name, parts[1], parts[0], qtype)
+	}
+	return nil, false
+}
+
+// service cluster ips of service, ready endpoints of headless service, or cname of external name service
+func (z *localZone) service(name, namespace, service string, qtype uint16) ([]miekgdns.RR, bool) {
+	svc, err := z.services.Services(namespace).Get(service)
+	if err != nil {
+		return nil, false
+	}
+	switch {
+	case svc.Spec.Type == v1.ServiceTypeExternalName:
+		return []miekgdns.RR{&miekgdns.CNAME{
+			Hdr:    header(name, miekgdns.TypeCNAME),
+			Target: miekgdns.Fqdn(svc.Spec.ExternalName),
+		}}, true
+	case svc.Spec.ClusterIP == v1.ClusterIPNone:
+		if z.slices == nil {
+			return nil, false
+		}
+		var rrs []miekgdns.RR
+		for _, ep := range z.endpoints(svc) {
+			for _, addr := range ep.Addresses {
+				rrs = appendIP(rrs, name, addr, qtype)
+			}
+		}
+		return rrs, true
+	default:
+		var rrs []miekgdns.RR
+		for _, ip := range svc.Spec.ClusterIPs {
+			rrs = appendIP(rrs, name, ip, qtype)
+		}
+		return rrs, true
+	}
+}
+
+// endpoint address of endpoint by hostname, eg: mongo-0 of statefulset, or dashed ip, eg: 10-244-0-12
+func (z *localZone) endpoint(name, namespace, service, host string, qtype uint16) ([]miekgdns.RR, bool) {
+	svc, err := z.services.Services(namespace).Get(service)
+	if err != nil || z.slices == nil {
+		return nil, false
+	}
+	var rrs []miekgdns.RR
+	for _, ep := range z.endpoints(svc) {
+		for _, addr := range ep.Addresses {
+			if endpointHost(ep, addr) == host {
+				rrs = appendIP(rrs, name, addr, qtype)
+			}
+		}
+	}
+	return rrs, true
+}
+
+// srv target is service for cluster ip service, or every endpoint for headless service
+func (z *localZone) srv(name, namespace, service, port, protocol string, qtype uint16) ([]miekgdns.RR, bool) {
+	svc, err := z.services.Services(namespace).Get(service)
+	if err != nil {
+		return nil, false
+	}
+	if qtype != miekgdns.TypeSRV && qtype != miekgdns.TypeANY {
+		return nil, true
+	}
+	var rrs []miekgdns.RR
+	var newSRV = func(target string, p int32) {
+		rrs = append(rrs, &miekgdns.SRV{
+			Hdr:      header(name, miekgdns.TypeSRV),
+			Priority: 0,
+			Weight:   100,
+			Port:     uint16(p),
+			Target:   target,
+		})
+	}
+	svcName := service + "." + namespace + ".svc." + z.domain
+	if svc.Spec.ClusterIP != v1.ClusterIPNone {
+		for _, p := range svc.Spec.Ports {
+			if p.Name == port && strings.EqualFold(string(p.Protocol), protocol) {
+				newSRV(svcName, p.Port)
+			}
+		}
+		return rrs, true
+	}
+	if z.slices == nil {
+		return nil, false
+	}
+	list, _ := z.slices.EndpointSlices(namespace).List(serviceSelector(service))
+	for _, slice := range list {
+		for _, p := range slice.Ports {
+			if p.Name == nil || *p.Name != port || p.Port == nil || p.Protocol == nil || !strings.EqualFold(string(*p.Protocol), protocol) {
+				continue
+			}
+			for _, ep := range readyEndpoints(svc, slice) {
+				for _, addr := range ep.Addresses {
+					newSRV(endpointHost(ep, addr)+"."+svcName, *p.Port)
+				}
+			}
+		}
+	}
+	return rrs, true
+}
+
+// pod ip of dashed ip, only pod which exists is answered
+func (z *localZone) pod(name, namespace, dashed string, qtype uint16) ([]miekgdns.RR, bool) {
+	if z.pods == nil {
+		return nil, false
+	}
+	ip := net.ParseIP(strings.ReplaceAll(dashed, "-", "."))
+	if ip == nil {
+		ip = net.ParseIP(strings.ReplaceAll(dashed, "-", ":"))
+	}
+	if ip == nil {
+		return nil, false
+	}
+	objs, err := z.pods.ByIndex(podIPIndex, ip.String())
+	if err != nil {
+		return nil, false
+	}
+	for _, obj := range objs {
+		if pod, ok := obj.(*v1.Pod); ok && pod.Namespace == namespace {
+			return appendIP(nil, name, ip.String(), qtype), true
+		}
+	}
+	return nil, false
+}
+
+func (z *localZone) endpoints(svc *v1.Service) (result []discoveryv1.Endpoint) {
+	list, _ := z.slices.EndpointSlices(svc.Namespace).List(serviceSelector(svc.Name))
+	for _, slice := range list {
+		result = append(result, readyEndpoints(svc, slice)...)
+	}
+	return
+}
+
+func serviceSelector(service string) labels.Selector {
+	return labels.SelectorFromSet(map[string]string{discoveryv1.LabelServiceName: service})
+}
+
+// readyEndpoints endpoints which are ready, or all of them if service publishes not ready addresses
+func readyEndpoints(svc *v1.Service, slice *discoveryv1.EndpointSlice) (result []discoveryv1.Endpoint) {
+	for _, ep := range slice.Endpoints {
+		if svc.Spec.PublishNotReadyAddresses || ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+			result = append(result, ep)
+		}
+	}
+	return
+}
+
+// endpointHost hostname of endpoint, or dashed ip if it has no hostname
+func endpointHost(ep discoveryv1.Endpoint, addr string) string {
+	if ep.Hostname != nil && *ep.Hostname != "" {
+		return *ep.Hostname
+	}
+	return strings.NewReplacer(".", "-", ":", "-").Replace(addr)
+}
+
+func appendIP(rrs []miekgdns.RR, name string, addr string, qtype uint16) []miekgdns.RR {
+	ip := net.ParseIP(addr)
+	switch {
+	case ip == nil:
+	case ip.To4() != nil && (qtype == miekgdns.TypeA || qtype == miekgdns.TypeANY):
+		rrs = append(rrs, &miekgdns.A{Hdr: header(name, miekgdns.TypeA), A: ip.To4()})
+	case ip.To4() == nil && (qtype == miekgdns.TypeAAAA || qtype == miekgdns.TypeANY):
+		rrs = append(rrs, &miekgdns.AAAA{Hdr: header(name, miekgdns.TypeAAAA), AAAA: ip})
+	}
+	return rrs
+}
+
+func header(name string, rrtype uint16) miekgdns.RR_Header {
+	return miekgdns.RR_Header{Name: name, Rrtype: rrtype, Class: miekgdns.ClassINET, Ttl: localTTL}
+}
@@ -0,0 +1,103 @@
+package dns
+
+import (
+	"testing"
+
+	miekgdns "github.com/miekg/dns"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+)
+
+func TestLocalZone(t *testing.T) {
+	services := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+	slices := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+	pods := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{podIPIndex: func(obj interface{}) ([]string, error) {
+		return []string{obj.(*v1.Pod).Status.PodIP}, nil
+	}})
+	_ = services.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "productpage", Namespace: "default"},
+		Spec: v1.ServiceSpec{ClusterIP: "10.96.0.10", ClusterIPs: []string{"10.96.0.10"}, Ports: []v1.ServicePort{
+			{Name: "http", Protocol: v1.ProtocolTCP, Port: 9080},
+		}},
+	})
+	_ = services.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "mongo", Namespace: "db"},
+		Spec:       v1.ServiceSpec{ClusterIP: v1.ClusterIPNone},
+	})
+	_ = services.Add(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "default"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "mysql.example.com"},
+	})
+	_ = slices.Add(&discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "mongo-abcde", Namespace: "db", Labels: map[string]string{discoveryv1.LabelServiceName: "mongo"}},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.244.0.5"}, Hostname: pointer.String("mongo-0"), Conditions: discoveryv1.EndpointConditions{Ready: pointer.Bool(true)}},
+			{Addresses: []string{"10.244.0.6"}, Hostname: pointer.String("mongo-1"), Conditions: discoveryv1.EndpointConditions{Ready: pointer.Bool(false)}},
+		},
+		Ports: []discoveryv1.EndpointPort{{Name: pointer.String("mongo"), Protocol: (*v1.Protocol)(pointer.String("TCP")), Port: pointer.Int32(27017)}},
+	})
+	_ = pods.Add(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "productpage-7c8d9", Namespace: "default"},
+		Status:     v1.PodStatus{PodIP: "10.244.0.12"},
+	})
+	zone := &localZone{
+		domain:   "cluster.local.",
+		services: corelisters.NewServiceLister(services),
+		slices:   discoverylisters.NewEndpointSliceLister(slices),
+		pods:     pods,
+	}
+	zone.synced.Store(true)
+
+	upstream := serveA(t, "example.com.", "1.2.3.4")
+	s := newServer(clientConfig(upstream, "default"), zone)
+	for _, c := range []struct {
+		name   string
+		qtype  uint16
+		expect []string
+	}{
+		{name: "productpage.", qtype: miekgdns.TypeA, expect: []string{"productpage.\t5\tIN\tA\t10.96.0.10"}},
+		{name: "mongo.db.", qtype: miekgdns.TypeA, expect: []string{"mongo.db.\t5\tIN\tA\t10.244.0.5"}},
+		{name: "mongo-0.mongo.db.svc.cluster.local.", qtype: miekgdns.TypeA, expect: []string{"mongo-0.mongo.db.svc.cluster.local.\t5\tIN\tA\t10.244.0.5"}},
+		{name: "mongo-1.mongo.db.svc.cluster.local.", qtype: miekgdns.TypeA},
+		{name: "_mongo._tcp.mongo.db.svc.cluster.local.", qtype: miekgdns.TypeSRV, expect: []string{"_mongo._tcp.mongo.db.svc.cluster.local.\t5\tIN\tSRV\t0 100 27017 mongo-0.mongo.db.svc.cluster.local."}},
+		{name: "_http._tcp.productpage.default.svc.cluster.local.", qtype: miekgdns.TypeSRV, expect: []string{"_http._tcp.productpage.default.svc.cluster.local.\t5\tIN\tSRV\t0 100 9080 productpage.default.svc.cluster.local."}},
+		{name: "mysql.", qtype: miekgdns.TypeA, expect: []string{"mysql.\t5\tIN\tCNAME\tmysql.example.com.", "mysql.example.com.\t60\tIN\tA\t1.2.3.4"}},
+		{name: "10-244-0-12.default.pod.cluster.local.", qtype: miekgdns.TypeA, expect: []string{"10-244-0-12.default.pod.cluster.local.\t5\tIN\tA\t10.244.0.12"}},
+	} {
+		r := new(miekgdns.Msg)
+		r.SetQuestion(c.name, c.qtype)
+		var answer *miekgdns.Msg
+		for _, name := range fix(c.name, s.forwardDNS.Search) {
+			var ok bool
+			if answer, ok = s.local(r, name); ok {
+				break
+			}
+		}
+		if answer == nil {
+			t.Fatalf("expect %s answered by local zone", c.name)
+		}
+		if len(answer.Answer) != len(c.expect) {
+			t.Fatalf("expect %d records of %s, but got %v", len(c.expect), c.name, answer.Answer)
+		}
+		for i, rr := range answer.Answer {
+			if rr.String() != c.expect[i] {
+				t.Fatalf("expect %s, but got %s", c.expect[i], rr.String())
+			}
+		}
+	}
+
+	// not in local zone, forwarded to cluster dns
+	r := new(miekgdns.Msg)
+	r.SetQuestion("reviews.default.svc.cluster.local.", miekgdns.TypeA)
+	if _, ok := s.local(r, r.Question[0].Name); ok {
+		t.Fatalf("expect reviews not in local zone")
+	}
+	if _, ok := s.local(r, "10-244-0-13.default.pod.cluster.local."); ok {
+		t.Fatalf("expect pod not exists is not in local zone")
+	}
+}
@@ -0,0 +1,259 @@
+//go:build linux
+
+package dns
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"unsafe"
+
+	"github.com/godbus/dbus/v5"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	resolvedDest    = "org.freedesktop.resolve1"
+	resolvedPath    = "/org/freedesktop/resolve1"
+	resolvedManager = "org.freedesktop.resolve1.Manager"
+
+	nmDest = "org.freedesktop.NetworkManager"
+	nmPath = "/org/freedesktop/NetworkManager"
+)
+
+type resolvedDNS struct {
+	Family  int32
+	Address []byte
+}
+
+type resolvedDNSEx struct {
+	Family  int32
+	Address []byte
+	Port    uint16
+	Name    string
+}
+
+type resolvedDomain struct {
+	Domain      string
+	RoutingOnly bool
+}
+
+// setLinkDNS set dns server and domains of tun by systemd-resolved, search domains are used for short names,
+// routing domains only route queries to dns server of tun. systemd-resolved drops them once tun is gone,
+// so nothing is left even if daemon crashed
+func setLinkDNS(tunName string, addr *net.UDPAddr, search, routing []string) (revert func(), err error) {
+	iface, err := net.InterfaceByName(tunName)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = conn.Close()
+		}
+	}()
+	ifindex := int32(iface.Index)
+	obj := conn.Object(resolvedDest, resolvedPath)
+	if addr.Port == 53 {
+		servers := []resolvedDNS{{Family: unix.AF_INET, Address: addr.IP.To4()}}
+		err = obj.Call(resolvedManager+".SetLinkDNS", 0, ifindex, servers).Err
+	} else {
+		// dns server with port needs systemd 246+
+		servers := []resolvedDNSEx{{Family: unix.AF_INET, Address: addr.IP.To4(), Port: uint16(addr.Port)}}
+		err = obj.Call(resolvedManager+".SetLinkDNSEx", 0, ifindex, servers).Err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to set dns of link %s: %v", tunName, err)
+	}
+	var domains []resolvedDomain
+	for _, s := range search {
+		domains = append(domains, resolvedDomain{Domain: s})
+	}
+	for _, s := range routing {
+		domains = append(domains, resolvedDomain{Domain: s, RoutingOnly: true})
+	}
+	if err = obj.Call(resolvedManager+".SetLinkDomains", 0, ifindex, domains).Err; err != nil {
+		_ = obj.Call(resolvedManager+".RevertLink", 0, ifindex).Err
+		return nil, fmt.Errorf("failed to set domains of link %s: %v", tunName, err)
+	}
+	// not use tun as default route of dns, needs systemd 240+
+	_ = obj.Call(resolvedManager+".SetLinkDefaultRoute", 0, ifindex, false).Err
+	_ = obj.Call(resolvedManager+".FlushCaches", 0).Err
+	return func() {
+		defer conn.Close()
+		if err := obj.Call(resolvedManager+".RevertLink", 0, ifindex).Err; err != nil {
+			log.Debugf("failed to revert dns of link %s: %v", tunName, err)
+		}
+		_ = obj.Call(resolvedManager+".FlushCaches", 0).Err
+	}, nil
+}
+
+// nmDeviceDNS dns of tun devices set by NetworkManager, tun may be shared by connections of clusters, device is
+// reapplied with domains of all of them, and original settings are reapplied after the last one is reverted
+type nmDeviceDNS struct {
+	lock sync.Mutex
+	// map[tun name]*nmDevice
+	devices map[string]*nmDevice
+}
+
+var nmDNS = &nmDeviceDNS{devices: map[string]*nmDevice{}}
+
+// nmDNSPriority same as vpn connection, lower value is higher priority, so domains of tun win over primary connection
+const nmDNSPriority = 50
+
+type nmDevice struct {
+	name    string
+	conn    *dbus.Conn
+	device  dbus.BusObject
+	origin  map[string]map[string]dbus.Variant
+	domains []*nmDomains
+}
+
+// nmDomains domains of one cluster
+type nmDomains struct {
+	search  []string
+	routing []string
+}
+
+// setDeviceDNS set dns server, search and routing domains of tun by NetworkManager, only applied connection of tun is
+// changed which is runtime state, profile is never touched, host device is never touched. it is refused if tun is not
+// managed by NetworkManager, caller should fallback to other way
+func setDeviceDNS(tunName string, addr *net.UDPAddr, search, routing []string) (revert func(), err error) {
+	if addr.Port != 53 {
+		return nil, fmt.Errorf("NetworkManager not support dns server on port %d", addr.Port)
+	}
+	nmDNS.lock.Lock()
+	defer nmDNS.lock.Unlock()
+	d, ok := nmDNS.devices[tunName]
+	if !ok {
+		if d, err = openDevice(tunName); err != nil {
+			return nil, err
+		}
+		nmDNS.devices[tunName] = d
+	}
+	domains := &nmDomains{search: search, routing: routing}
+	d.domains = append(d.domains, domains)
+	if err = d.apply(addr); err != nil {
+		nmDNS.release(d, domains, addr)
+		return nil, fmt.Errorf("failed to reapply dns of tun %s: %v", tunName, err)
+	}
+	return func() {
+		nmDNS.lock.Lock()
+		defer nmDNS.lock.Unlock()
+		nmDNS.release(d, domains, addr)
+	}, nil
+}
+
+// release domains of cluster, original settings are reapplied if no cluster uses tun
+func (n *nmDeviceDNS) release(d *nmDevice, domains *nmDomains, addr *net.UDPAddr) {
+	for i, v := range d.domains {
+		if v == domains {
+			d.domains = append(d.domains[:i], d.domains[i+1:]...)
+			break
+		}
+	}
+	if len(d.domains) != 0 {
+		if err := d.apply(addr); err != nil {
+			log.Debugf("failed to reapply dns of tun %s: %v", d.name, err)
+		}
+		return
+	}
+	delete(n.devices, d.name)
+	defer d.conn.Close()
+	var current map[string]map[string]dbus.Variant
+	var version uint64
+	err := d.device.Call(nmDest+".Device.GetAppliedConnection", 0, uint32(0)).Store(&current, &version)
+	if err == nil {
+		err = reapply(d.device, d.origin, version)
+	}
+	if err != nil {
+		log.Debugf("failed to revert dns of tun %s: %v", d.name, err)
+	}
+}
+
+// openDevice device of tun, it must be managed by NetworkManager and has applied connection
+func openDevice(tunName string) (d *nmDevice, err error) {
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = conn.Close()
+		}
+	}()
+	var path dbus.ObjectPath
+	if err = conn.Object(nmDest, nmPath).Call(nmDest+".GetDeviceByIpIface", 0, tunName).Store(&path); err != nil {
+		return nil, fmt.Errorf("tun %s is not found by NetworkManager: %v", tunName, err)
+	}
+	device := conn.Object(nmDest, path)
+	managed, err := device.GetProperty(nmDest + ".Device.Managed")
+	if err != nil {
+		return nil, err
+	}
+	if ok, _ := managed.Value().(bool); !ok {
+		return nil, fmt.Errorf("tun %s is not managed by NetworkManager", tunName)
+	}
+	var origin map[string]map[string]dbus.Variant
+	var version uint64
+	if err = device.Call(nmDest+".Device.GetAppliedConnection", 0, uint32(0)).Store(&origin, &version); err != nil {
+		return nil, fmt.Errorf("tun %s has no connection of NetworkManager: %v", tunName, err)
+	}
+	return &nmDevice{name: tunName, conn: conn, device: device, origin: origin}, nil
+}
+
+// apply dns server and domains of all clusters to original settings of tun, routing domains are prefixed with ~
+func (d *nmDevice) apply(addr *net.UDPAddr) error {
+	var current map[string]map[string]dbus.Variant
+	var version uint64
+	if err := d.device.Call(nmDest+".Device.GetAppliedConnection", 0, uint32(0)).Store(&current, &version); err != nil {
+		return err
+	}
+	settings := make(map[string]map[string]dbus.Variant)
+	for k, v := range d.origin {
+		settings[k] = make(map[string]dbus.Variant)
+		for kk, vv := range v {
+			settings[k][kk] = vv
+		}
+	}
+	if settings["ipv4"] == nil {
+		settings["ipv4"] = make(map[string]dbus.Variant)
+	}
+	// ipv4 dns of NetworkManager is uint32 which memory layout is in network byte order
+	ip := addr.IP.To4()
+	server := *(*uint32)(unsafe.Pointer(&ip[0]))
+	var searches []string
+	seen := sets.New[string]()
+	for _, domains := range d.domains {
+		for _, s := range domains.search {
+			if !seen.Has(s) {
+				seen.Insert(s)
+				searches = append(searches, s)
+			}
+		}
+		for _, s := range domains.routing {
+			if s = "~" + s; !seen.Has(s) {
+				seen.Insert(s)
+				searches = append(searches, s)
+			}
+		}
+	}
+	settings["ipv4"]["dns"] = dbus.MakeVariant([]uint32{server})
+	settings["ipv4"]["dns-search"] = dbus.MakeVariant(searches)
+	settings["ipv4"]["dns-priority"] = dbus.MakeVariant(int32(nmDNSPriority))
+	return reapply(d.device, settings, version)
+}
+
+func reapply(device dbus.BusObject, settings map[string]map[string]dbus.Variant, version uint64) error {
+	// deprecated addresses and routes conflict with address-data and route-data
+	for _, family := range []string{"ipv4", "ipv6"} {
+		delete(settings[family], "addresses")
+		delete(settings[family], "routes")
+	}
+	return device.Call(nmDest+".Device.Reapply", 0, settings, version, uint32(0)).Err
+}
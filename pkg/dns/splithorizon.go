@@ -0,0 +1,289 @@
+package dns
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// splitDNS split-horizon dns server shared by connections of all clusters in daemon
+var splitDNS = &splitServer{
+	client: &miekgdns.Client{Net: "udp", Timeout: time.Second * 5},
+}
+
+// splitServer split-horizon dns server, only cluster zones are answered by cluster dns, others are forwarded to
+// upstream dns which is used before connect, so it does not take over resolving of names outside cluster.
+// unqualified names, eg: productpage, productpage.default, productpage.default.svc.cluster.local are answered by
+// primary cluster, names qualified by svc.<kubeconfig context> are answered by that cluster, eg: productpage.default.svc.kind-kind,
+// other names under context, eg: www.dev are not taken over, context may be a real top level domain
+type splitServer struct {
+	lock     sync.RWMutex
+	clusters []*clusterZone
+	upstream []string // upstream dns, host:port
+	client   *miekgdns.Client
+
+	srv  *miekgdns.Server
+	addr *net.UDPAddr
+}
+
+// clusterZone zones of one cluster
+type clusterZone struct {
+	qualifier string // eg: svc.kind-kind.
+	primary   bool
+	cluster   *server
+	servers   []string // cluster dns, host:port
+	ns        sets.Set[string]
+	zones     []string // eg: default.svc.cluster.local., svc.cluster.local., cluster.local.
+	svcZone   string   // eg: svc.cluster.local.
+	extra     []string // extra domains, resolved by cluster dns as it is
+}
+
+func newClusterZone(context string, primary bool, clientConfig *miekgdns.ClientConfig, ns []string, extraDomain []string, zone *localZone) *clusterZone {
+	z := &clusterZone{
+		primary: primary,
+		cluster: newServer(clientConfig, zone),
+		ns:      sets.New[string](ns...),
+		svcZone: "svc.cluster.local.",
+	}
+	if q := qualifier(context); q != "" {
+		z.qualifier = "svc." + q + "."
+	}
+	for _, server := range clientConfig.Servers {
+		z.servers = append(z.servers, net.JoinHostPort(server, clientConfig.Port))
+	}
+	for _, search := range clusterSearch(clientConfig.Search) {
+		zone := miekgdns.Fqdn(strings.ToLower(search))
+		z.zones = append(z.zones, zone)
+		if strings.HasPrefix(zone, "svc.") {
+			z.svcZone = zone
+		}
+	}
+	for _, domain := range extraDomain {
+		z.extra = append(z.extra, miekgdns.Fqdn(strings.ToLower(domain)))
+	}
+	return z
+}
+
+// serve start split dns server on conn which is returned by listen if it is not running,
+// upstream is called with address of split dns server, it should not contain that address
+func (s *splitServer) serve(listen func() (net.PacketConn, error), upstream func(*net.UDPAddr) []string) (*net.UDPAddr, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.srv != nil {
+		return s.addr, nil
+	}
+	pc, err := listen()
+	if err != nil {
+		return nil, err
+	}
+	s.addr = pc.LocalAddr().(*net.UDPAddr)
+	s.upstream = upstream(s.addr)
+	s.srv = &miekgdns.Server{PacketConn: pc, Handler: s}
+	go func(srv *miekgdns.Server) {
+		if err := srv.ActivateAndServe(); err != nil {
+			log.Errorf("split dns server exited: %v", err)
+		}
+	}(s.srv)
+	log.Debugf("split dns server listen on %s, upstream: %v", s.addr, s.upstream)
+	return s.addr, nil
+}
+
+func (s *splitServer) add(z *clusterZone) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.clusters = append(append([]*clusterZone{}, s.clusters...), z)
+}
+
+// remove zones of cluster, split dns server is shutdown if no cluster left
+func (s *splitServer) remove(z *clusterZone) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// not modify slice in place, ServeDNS may be iterating it
+	var clusters []*clusterZone
+	for _, c := range s.clusters {
+		if c != z {
+			clusters = append(clusters, c)
+		}
+	}
+	s.clusters = clusters
+	if len(s.clusters) == 0 && s.srv != nil {
+		_ = s.srv.Shutdown()
+		s.srv = nil
+	}
+}
+
+func (s *splitServer) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+	s.lock.RLock()
+	clusters := s.clusters
+	upstream := s.upstream
+	s.lock.RUnlock()
+
+	if len(r.Question) == 0 {
+		_ = w.WriteMsg(new(miekgdns.Msg).SetRcode(r, miekgdns.RcodeFormatError))
+		_ = w.Close()
+		return
+	}
+	name := strings.ToLower(r.Question[0].Name)
+	// the last added primary cluster answers unqualified names
+	var primary *clusterZone
+	for _, z := range clusters {
+		if inZones(name, z.extra) {
+			s.forward(w, r, z.servers)
+			return
+		}
+		if z.qualifier != "" && miekgdns.IsSubDomain(z.qualifier, name) {
+			s.forwardQualified(w, r, z)
+			return
+		}
+		if z.primary {
+			primary = z
+		}
+	}
+	if primary != nil && primary.isCluster(name) {
+		primary.cluster.ServeDNS(w, r)
+		return
+	}
+	// no upstream to forward to, eg: resolv.conf only has stub of systemd-resolved which routes it back here,
+	// refuse it instead of looping until timeout
+	if len(upstream) == 0 {
+		_ = w.WriteMsg(new(miekgdns.Msg).SetRcode(r, miekgdns.RcodeRefused))
+		_ = w.Close()
+		return
+	}
+	s.forward(w, r, upstream)
+}
+
+// isCluster name is in cluster zones, or short name like: service., service.namespace., service.namespace.svc.
+func (z *clusterZone) isCluster(name string) bool {
+	if inZones(name, z.zones) {
+		return true
+	}
+	labels := miekgdns.SplitDomainName(name)
+	if len(labels) <= 1 {
+		return len(labels) == 1
+	}
+	if z.ns.Has(labels[1]) {
+		rest := strings.Join(labels[2:], ".")
+		return rest == "" || strings.HasPrefix(z.svcZone, rest+".")
+	}
+	return false
+}
+
+// unqualify replace qualifier of name with cluster domain, eg: productpage.default.svc.kind-kind. ->
+// productpage.default.svc.cluster.local.
+func (z *clusterZone) unqualify(name string) string {
+	labels := miekgdns.SplitDomainName(strings.TrimSuffix(name, z.qualifier))
+	if len(labels) == 0 {
+		return z.svcZone
+	}
+	return strings.Join(labels, ".") + "." + z.svcZone
+}
+
+func (z *clusterZone) qualify(name string) string {
+	lower := strings.ToLower(name)
+	if miekgdns.IsSubDomain(z.svcZone, lower) {
+		return strings.TrimSuffix(lower, z.svcZone) + z.qualifier
+	}
+	return name
+}
+
+func (s *splitServer) forwardQualified(w miekgdns.ResponseWriter, r *miekgdns.Msg, z *clusterZone) {
+	defer w.Close()
+	dnsStats.queries.Add(1)
+	start := time.Now()
+	name := r.Question[0].Name
+	msg := r.Copy()
+	msg.Question[0].Name = z.unqualify(strings.ToLower(name))
+	var reply = func(answer *miekgdns.Msg) {
+		answer.Question = r.Question
+		for _, rr := range answer.Answer {
+			if strings.EqualFold(rr.Header().Name, msg.Question[0].Name) {
+				rr.Header().Name = name
+			} else {
+				rr.Header().Name = z.qualify(rr.Header().Name)
+			}
+			switch rr := rr.(type) {
+			case *miekgdns.CNAME:
+				rr.Target = z.qualify(rr.Target)
+			case *miekgdns.SRV:
+				rr.Target = z.qualify(rr.Target)
+			}
+		}
+		_ = w.WriteMsg(answer)
+	}
+	if answer, ok := z.cluster.local(msg, msg.Question[0].Name); ok {
+		reply(answer)
+		return
+	}
+	var err error
+	for _, addr := range z.servers {
+		var answer *miekgdns.Msg
+		answer, _, err = s.client.Exchange(msg, addr)
+		if err == nil {
+			dnsStats.observe(time.Since(start), true)
+			reply(answer)
+			return
+		}
+	}
+	dnsStats.observe(time.Since(start), false)
+	log.Debugf("[dns] forward %s as %s failed: %v", name, msg.Question[0].Name, err)
+	_ = w.WriteMsg(new(miekgdns.Msg).SetRcode(r, miekgdns.RcodeServerFailure))
+}
+
+// forward query to servers one by one, reply server failure if all of them failed
+func (s *splitServer) forward(w miekgdns.ResponseWriter, r *miekgdns.Msg, servers []string) {
+	defer w.Close()
+	dnsStats.queries.Add(1)
+	start := time.Now()
+	var err error
+	for _, addr := range servers {
+		var answer *miekgdns.Msg
+		answer, _, err = s.client.Exchange(r, addr)
+		if err == nil {
+			dnsStats.observe(time.Since(start), true)
+			_ = w.WriteMsg(answer)
+			return
+		}
+	}
+	dnsStats.observe(time.Since(start), false)
+	if err != nil {
+		log.Debugf("[dns] forward %s failed: %v", r.Question[0].Name, err)
+	}
+	_ = w.WriteMsg(new(miekgdns.Msg).SetRcode(r, miekgdns.RcodeServerFailure))
+}
+
+func inZones(name string, zones []string) bool {
+	for _, zone := range zones {
+		if miekgdns.IsSubDomain(zone, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// clusterSearch search of cluster dns, like: default.svc.cluster.local svc.cluster.local cluster.local,
+// the others are search of node, eg: ec2.internal, they are not cluster zones
+func clusterSearch(search []string) []string {
+	if len(search) > 3 {
+		return search[:3]
+	}
+	return search
+}
+
+var invalidLabel = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// qualifier dns label of kubeconfig context, eg: kind-kind -> kind-kind, arn:aws:eks:us-east-1:123:cluster/prod ->
+// arn-aws-eks-us-east-1-123-cluster-prod
+func qualifier(context string) string {
+	label := strings.Trim(invalidLabel.ReplaceAllString(strings.ToLower(context), "-"), "-")
+	if len(label) > 63 {
+		label = strings.TrimRight(label[:63], "-")
+	}
+	return label
+}
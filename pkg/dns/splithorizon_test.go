@@ -0,0 +1,123 @@
+package dns
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+// serveA answer A record of names in zone with ip
+func serveA(t *testing.T, zone string, ip string) *net.UDPAddr {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &miekgdns.Server{PacketConn: pc, Handler: miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+		msg := new(miekgdns.Msg)
+		msg.SetReply(r)
+		if !miekgdns.IsSubDomain(zone, r.Question[0].Name) {
+			msg.Rcode = miekgdns.RcodeNameError
+		} else {
+			msg.Answer = append(msg.Answer, &miekgdns.A{
+				Hdr: miekgdns.RR_Header{Name: r.Question[0].Name, Rrtype: miekgdns.TypeA, Class: miekgdns.ClassINET, Ttl: 60},
+				A:   net.ParseIP(ip),
+			})
+		}
+		_ = w.WriteMsg(msg)
+	})}
+	go srv.ActivateAndServe()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return pc.LocalAddr().(*net.UDPAddr)
+}
+
+func clientConfig(addr *net.UDPAddr, namespace string) *miekgdns.ClientConfig {
+	return &miekgdns.ClientConfig{
+		Servers: []string{addr.IP.String()},
+		Port:    strconv.Itoa(addr.Port),
+		Search:  []string{namespace + ".svc.cluster.local", "svc.cluster.local", "cluster.local", "ec2.internal"},
+	}
+}
+
+func TestSplitServer(t *testing.T) {
+	primary := serveA(t, "cluster.local.", "10.0.0.1")
+	lite := serveA(t, "cluster.local.", "10.0.0.2")
+	upstream := serveA(t, ".", "8.8.8.8")
+
+	s := &splitServer{client: &miekgdns.Client{Net: "udp"}}
+	listen := func() (net.PacketConn, error) {
+		return net.ListenPacket("udp", "127.0.0.1:0")
+	}
+	addr, err := s.serve(listen, func(*net.UDPAddr) []string { return []string{upstream.String()} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	kind := newClusterZone("kind-kind", true, clientConfig(primary, "default"), []string{"default", "kube-system"}, nil, nil)
+	s.add(kind)
+	defer s.remove(kind)
+	prod := newClusterZone("arn:aws:eks:us-east-1:123:cluster/prod", false, clientConfig(lite, "default"), []string{"default"}, nil, nil)
+	s.add(prod)
+	defer s.remove(prod)
+
+	client := &miekgdns.Client{Net: "udp"}
+	for name, expect := range map[string]string{
+		"productpage.default.svc.cluster.local.":                          "10.0.0.1",
+		"productpage.":                                                    "10.0.0.1",
+		"productpage.default.":                                            "10.0.0.1",
+		"kube-dns.kube-system.svc.":                                       "10.0.0.1",
+		"productpage.default.svc.kind-kind.":                              "10.0.0.1",
+		"productpage.default.svc.arn-aws-eks-us-east-1-123-cluster-prod.": "10.0.0.2",
+		"productpage.default.arn-aws-eks-us-east-1-123-cluster-prod.":     "8.8.8.8",
+		"www.kind-kind.":                                                  "8.8.8.8",
+		"www.example.com.":                                                "8.8.8.8",
+		"www.default.com.":                                                "8.8.8.8",
+		"host.ec2.internal.":                                              "8.8.8.8",
+	} {
+		msg := new(miekgdns.Msg)
+		msg.SetQuestion(name, miekgdns.TypeA)
+		answer, _, err := client.Exchange(msg, addr.String())
+		if err != nil {
+			t.Fatalf("query %s failed: %v", name, err)
+		}
+		if len(answer.Answer) != 1 || answer.Answer[0].(*miekgdns.A).A.String() != expect {
+			t.Fatalf("expect %s resolved to %s, but got %v", name, expect, answer.Answer)
+		}
+		if answer.Answer[0].Header().Name != name {
+			t.Fatalf("expect answer of %s, but got %s", name, answer.Answer[0].Header().Name)
+		}
+	}
+}
+
+func TestSplitServerWithoutUpstream(t *testing.T) {
+	primary := serveA(t, "cluster.local.", "10.0.0.1")
+
+	s := &splitServer{client: &miekgdns.Client{Net: "udp"}}
+	listen := func() (net.PacketConn, error) {
+		return net.ListenPacket("udp", "127.0.0.1:0")
+	}
+	addr, err := s.serve(listen, func(*net.UDPAddr) []string { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	kind := newClusterZone("kind-kind", true, clientConfig(primary, "default"), []string{"default", "dev"}, nil, nil)
+	s.add(kind)
+	defer s.remove(kind)
+
+	client := &miekgdns.Client{Net: "udp"}
+	for name, rcode := range map[string]int{
+		"productpage.dev.svc.cluster.local.": miekgdns.RcodeSuccess,
+		"www.google.dev.":                    miekgdns.RcodeRefused,
+		"www.example.com.":                   miekgdns.RcodeRefused,
+	} {
+		msg := new(miekgdns.Msg)
+		msg.SetQuestion(name, miekgdns.TypeA)
+		answer, _, err := client.Exchange(msg, addr.String())
+		if err != nil {
+			t.Fatalf("query %s failed: %v", name, err)
+		}
+		if answer.Rcode != rcode {
+			t.Fatalf("expect rcode of %s is %s, but got %s", name, miekgdns.RcodeToString[rcode], miekgdns.RcodeToString[answer.Rcode])
+		}
+	}
+}
@@ -24,14 +24,8 @@ func AddContainer(spec *corev1.PodSpec, c util.PodRouteConfig) {
 	spec.Containers = append(spec.Containers, corev1.Container{
 		Name:  config.ContainerSidecarVPN,
 		Image: config.Image,
-		EnvFrom: []corev1.EnvFromSource{{
-			SecretRef: &corev1.SecretEnvSource{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: config.ConfigMapPodTrafficManager,
-				},
-			},
-		}},
 		Env: []corev1.EnvVar{
+			util.EnvFromTrafficManagerSecret(config.TLSCertKey),
 			{
 				Name:  "LocalTunIPv4",
 				Value: c.LocalTunIPv4,
@@ -48,6 +42,10 @@ func AddContainer(spec *corev1.PodSpec, c util.PodRouteConfig) {
 				Name:  config.EnvInboundPodTunIPv6,
 				Value: "",
 			},
+			{
+				Name:  config.EnvTunnelKey,
+				Value: "",
+			},
 			{
 				Name:  "CIDR4",
 				Value: config.CIDR.String(),
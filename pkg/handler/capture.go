@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/wencaiwulue/kubevpn/pkg/util"
+)
+
+// CapturePort traffic manager serve packet capture on localhost of pod, it is only reachable by port-forward
+const CapturePort = "9101"
+
+// CaptureTrafficManager capture packets routed by traffic manager, pcapng is streamed from capture port of every
+// running traffic manager pod through port-forward, so it needs same permission as connect. clients may be attached
+// to any replica, streams are merged into one pcapng, each pod is one interface which is named by pod
+func (c *ConnectOptions) CaptureTrafficManager(ctx context.Context, w io.Writer, filter string) error {
+	pods, err := c.GetRunningPodList(ctx)
+	if err != nil {
+		return err
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	readers := make([]*pcapgo.NgReader, len(pods))
+	for i := range pods {
+		if readers[i], err = c.capturePod(cctx, pods[i].Name, filter); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+	writer, err := newCaptureWriter(w, pods)
+	if err != nil {
+		return err
+	}
+
+	var lock sync.Mutex
+	errChan := make(chan error, len(readers))
+	for i, r := range readers {
+		go func(id int, r *pcapgo.NgReader) {
+			for {
+				data, ci, err := r.ReadPacketData()
+				if err != nil {
+					errChan <- fmt.Errorf("failed to capture packets of %s, err: %v", pods[id].Name, err)
+					return
+				}
+				ci.InterfaceIndex = id
+				lock.Lock()
+				if err = writer.WritePacket(ci, data); err == nil {
+					err = writer.Flush()
+				}
+				lock.Unlock()
+				if err != nil {
+					errChan <- err
+					return
+				}
+			}
+		}(i, r)
+	}
+	select {
+	case err = <-errChan:
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// newCaptureWriter pcapng writer with one interface per traffic manager pod
+func newCaptureWriter(w io.Writer, pods []v1.Pod) (*pcapgo.NgWriter, error) {
+	var intf = func(pod v1.Pod) pcapgo.NgInterface {
+		return pcapgo.NgInterface{Name: pod.Name, Description: pod.Status.PodIP, LinkType: layers.LinkTypeRaw}
+	}
+	writer, err := pcapgo.NewNgWriterInterface(w, intf(pods[0]), pcapgo.DefaultNgWriterOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods[1:] {
+		if _, err = writer.AddInterface(intf(pod)); err != nil {
+			return nil, err
+		}
+	}
+	return writer, writer.Flush()
+}
+
+// capturePod port-forward capture port of traffic manager pod until ctx is done, and read pcapng stream of it
+func (c *ConnectOptions) capturePod(ctx context.Context, podName string, filter string) (*pcapgo.NgReader, error) {
+	port, err := util.GetAvailableTCPPortOrDie()
+	if err != nil {
+		return nil, err
+	}
+	readyChan := make(chan struct{})
+	stopChan := make(chan struct{})
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- util.PortForwardPod(c.config, c.restclient, podName, c.Namespace,
+			[]string{fmt.Sprintf("%d:%s", port, CapturePort)}, readyChan, stopChan)
+	}()
+	go func() {
+		<-ctx.Done()
+		close(stopChan)
+	}()
+	select {
+	case <-readyChan:
+	case err = <-errChan:
+		return nil, fmt.Errorf("failed to port-forward capture port of %s, err: %v", podName, err)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	u := fmt.Sprintf("http://127.0.0.1:%d/capture?filter=%s", port, url.QueryEscape(filter))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture packets of %s, err: %v", podName, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to capture packets of %s, err: %s", podName, msg)
+	}
+	// body is closed once ctx is done
+	r, err := pcapgo.NewNgReader(resp.Body, pcapgo.NgReaderOptions{})
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to read pcapng of %s, err: %v", podName, err)
+	}
+	return r, nil
+}
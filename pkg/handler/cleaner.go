@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
@@ -47,6 +48,11 @@ func (c *ConnectOptions) Cleanup() {
 	if c.localTunIPv6 != nil && c.localTunIPv6.IP != nil {
 		ips = append(ips, c.localTunIPv6.IP)
 	}
+	if c.clientset != nil && c.localTunIPv4 != nil && len(c.tunnelKey) != 0 {
+		if err := c.tunnelKeyProof(ctx, http.MethodDelete); err != nil {
+			log.Errorf("failed to revoke tunnel key, err: %v", err)
+		}
+	}
 	if c.dhcp != nil {
 		err := c.dhcp.ReleaseIP(ctx, ips...)
 		if err != nil {
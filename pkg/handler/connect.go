@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/rand"
 	"net"
+	"net/http"
 	"net/netip"
 	"net/url"
 	"os"
@@ -16,6 +17,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/containernetworking/cni/pkg/types"
@@ -62,6 +64,7 @@ import (
 	pkgclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/controlplane"
 	"github.com/wencaiwulue/kubevpn/pkg/core"
 	"github.com/wencaiwulue/kubevpn/pkg/dns"
 	"github.com/wencaiwulue/kubevpn/pkg/driver"
@@ -72,11 +75,23 @@ import (
 type ConnectOptions struct {
 	Namespace            string
 	Headers              map[string]string
+	Weight               uint32
+	Mirror               bool
+	Fallback             bool
+	SourceLabels         map[string]string
+	SourceIPs            []string
+	ServerNames          []string
 	Workloads            []string
 	ExtraCIDR            []string
 	ExtraDomain          []string
 	UseLocalDNS          bool
 	Engine               config.Engine
+	Streams              int32
+	MTU                  int32
+	Transport            config.Transport
+	TransportAddr        string
+	PrimaryDNS           bool
+	Replicas             int32
 	Foreground           bool
 	OriginKubeconfigPath string
 
@@ -90,8 +105,12 @@ type ConnectOptions struct {
 	cidrs      []*net.IPNet
 	dhcp       *DHCPManager
 	// needs to give it back to dhcp
-	localTunIPv4     *net.IPNet
-	localTunIPv6     *net.IPNet
+	localTunIPv4 *net.IPNet
+	localTunIPv6 *net.IPNet
+	tunnelKey    string
+	// chain node of gvisor tcp and udp forwarders of traffic manager
+	gvisorTCPForward string
+	gvisorUDPForward string
 	rollbackFuncList []func() error
 	dnsConfig        *dns.Config
 
@@ -107,7 +126,7 @@ func (c *ConnectOptions) Context() context.Context {
 }
 
 func (c *ConnectOptions) InitDHCP(ctx context.Context) error {
-	c.dhcp = NewDHCPManager(c.clientset.CoreV1().ConfigMaps(c.Namespace), c.Namespace)
+	c.dhcp = NewDHCPManager(c.clientset.CoreV1().ConfigMaps(c.Namespace), nil, c.Namespace)
 	err := c.dhcp.initDHCP(ctx)
 	return err
 }
@@ -156,6 +175,23 @@ func (c *ConnectOptions) CreateRemoteInboundPod(ctx context.Context) (err error)
 	if c.localTunIPv4 == nil || c.localTunIPv6 == nil {
 		return fmt.Errorf("local tun ip is invalid")
 	}
+	if err = controlplane.ValidateHeaders(c.Headers); err != nil {
+		return err
+	}
+	if c.Weight > 100 {
+		return fmt.Errorf("invalid weight %d, range is [0, 100]", c.Weight)
+	}
+	if c.Mirror && c.Weight != 0 {
+		return fmt.Errorf("weight can not be used with mirror mode")
+	}
+	if c.Mirror && c.Fallback {
+		return fmt.Errorf("fallback can not be used with mirror mode")
+	}
+	for _, ip := range c.SourceIPs {
+		if _, err = controlplane.ParseCIDR(ip); err != nil {
+			return err
+		}
+	}
 
 	for _, workload := range c.Workloads {
 		log.Infof("start to create remote inbound pod for %s", workload)
@@ -166,8 +202,25 @@ func (c *ConnectOptions) CreateRemoteInboundPod(ctx context.Context) (err error)
 		// todo consider to use ephemeral container
 		// https://kubernetes.io/docs/concepts/workloads/pods/ephemeral-containers/
 		// means mesh mode
-		if len(c.Headers) != 0 {
-			err = InjectVPNAndEnvoySidecar(ctx, c.factory, c.clientset.CoreV1().ConfigMaps(c.Namespace), c.Namespace, workload, configInfo, c.Headers)
+		if len(c.Headers) != 0 || c.Weight != 0 || c.Mirror || c.Fallback || len(c.SourceLabels) != 0 || len(c.SourceIPs) != 0 || len(c.ServerNames) != 0 {
+			weight := c.Weight
+			// only fallback, route all traffic to local PC, fail over to origin workloads if local PC is unreachable
+			if c.Fallback && len(c.Headers) == 0 && c.Weight == 0 && !c.Mirror && len(c.SourceLabels) == 0 && len(c.SourceIPs) == 0 && len(c.ServerNames) == 0 {
+				weight = 100
+			}
+			rule := &controlplane.Rule{
+				Headers:      c.Headers,
+				LocalTunIPv4: configInfo.LocalTunIPv4,
+				LocalTunIPv6: configInfo.LocalTunIPv6,
+				Weight:       weight,
+				Mirror:       c.Mirror,
+				Fallback:     c.Fallback,
+				SourceLabels: c.SourceLabels,
+				SourceIPs:    c.SourceIPs,
+				ServerNames:  c.ServerNames,
+				ExpireAt:     &metav1.Time{Time: time.Now().Add(controlplane.RuleLeaseDuration)},
+			}
+			err = InjectVPNAndEnvoySidecar(ctx, c.factory, c.clientset.CoreV1().ConfigMaps(c.Namespace), c.Namespace, workload, configInfo, rule)
 		} else {
 			err = InjectVPNSidecar(ctx, c.factory, c.Namespace, workload, configInfo)
 		}
@@ -218,45 +271,45 @@ func (c *ConnectOptions) DoConnect(ctx context.Context, isLite bool) (err error)
 		return
 	}
 	log.Info("get cidr successfully")
-	if err = createOutboundPod(c.ctx, c.factory, c.clientset, c.Namespace); err != nil {
+	if err = createOutboundPod(c.ctx, c.factory, c.clientset, c.Namespace, c.Replicas); err != nil {
 		return
 	}
 	if err = c.setImage(c.ctx); err != nil {
 		return
 	}
+	if err = c.requestTunnelKey(c.ctx); err != nil {
+		log.Errorf("request tunnel key failed: %v", err)
+		return
+	}
 	//if err = c.CreateRemoteInboundPod(c.ctx); err != nil {
 	//	return
 	//}
-	var rawTCPForwardPort, gvisorTCPForwardPort, gvisorUDPForwardPort int
-	rawTCPForwardPort, err = util.GetAvailableTCPPortOrDie()
-	if err != nil {
-		return err
-	}
-	gvisorTCPForwardPort, err = util.GetAvailableTCPPortOrDie()
-	if err != nil {
-		return err
-	}
-	gvisorUDPForwardPort, err = util.GetAvailableTCPPortOrDie()
-	if err != nil {
-		return err
-	}
-	if err = c.portForward(c.ctx, []string{
-		fmt.Sprintf("%d:10800", rawTCPForwardPort),
-		fmt.Sprintf("%d:10801", gvisorTCPForwardPort),
-		fmt.Sprintf("%d:10802", gvisorUDPForwardPort),
-	}); err != nil {
+	var forward string
+	if forward, err = c.forwardAddr(c.ctx); err != nil {
 		return
 	}
 	if util.IsWindows() {
 		driver.InstallWireGuardTunDriver()
 	}
-	forward := fmt.Sprintf("tcp://127.0.0.1:%d", rawTCPForwardPort)
-	core.GvisorTCPForwardAddr = fmt.Sprintf("tcp://127.0.0.1:%d", gvisorTCPForwardPort)
-	core.GvisorUDPForwardAddr = fmt.Sprintf("tcp://127.0.0.1:%d", gvisorUDPForwardPort)
+	var query []string
+	// keep tcp connections over tunnel alive if port-forward drops
+	if !strings.HasPrefix(forward, "mtcp://") {
+		query = append(query, "session=true")
+	}
+	if len(c.tunnelKey) != 0 {
+		query = append(query, "key="+c.tunnelKey)
+		// gvisor forwarders dial any address, traffic manager only accept them after handshake sealed by tunnel key
+		c.gvisorTCPForward = withQuery(c.gvisorTCPForward, "key="+c.tunnelKey)
+		c.gvisorUDPForward = withQuery(c.gvisorUDPForward, "key="+c.tunnelKey)
+	}
+	forward = withQuery(forward, query...)
 	if err = c.startLocalTunServe(c.ctx, forward, isLite); err != nil {
 		log.Errorf("start local tun service failed: %v", err)
 		return
 	}
+	if c.MTU <= 0 {
+		go c.probePathMTU(c.ctx)
+	}
 	log.Infof("adding route...")
 	if err = c.addRouteDynamic(c.ctx); err != nil {
 		log.Errorf("add route dynamic failed: %v", err)
@@ -272,10 +325,107 @@ func (c *ConnectOptions) DoConnect(ctx context.Context, isLite bool) (err error)
 		return
 	}
 	go c.heartbeats(c.ctx)
+	go c.renewRules(c.ctx)
+	go c.renewTunnelKey(c.ctx)
 	log.Info("dns service ok")
 	return
 }
 
+// probeQUIC find address of traffic manager quic port which is reachable from local, like LoadBalancer ingress,
+// external ip or NodePort, address inside cidrs is skipped, because it will be routed into tun device.
+// returns chain node of quic which pins certificate of traffic manager
+func (c *ConnectOptions) probeQUIC(ctx context.Context) (string, bool) {
+	svc, err := c.clientset.CoreV1().Services(c.Namespace).Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+	if err != nil {
+		log.Debugf("get service %s failed: %v", config.ConfigMapPodTrafficManager, err)
+		return "", false
+	}
+	var port *v1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Protocol == v1.ProtocolUDP && svc.Spec.Ports[i].TargetPort.IntValue() == 10803 {
+			port = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if port == nil {
+		log.Debugf("service %s not expose quic port", config.ConfigMapPodTrafficManager)
+		return "", false
+	}
+
+	var addrs []string
+	var add = func(host string, p int32) {
+		if ip := net.ParseIP(host); ip != nil {
+			for _, cidr := range c.cidrs {
+				if cidr.Contains(ip) {
+					return
+				}
+			}
+		}
+		if len(host) != 0 && p != 0 {
+			addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(p))))
+		}
+	}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		add(ingress.IP, port.Port)
+		add(ingress.Hostname, port.Port)
+	}
+	for _, ip := range svc.Spec.ExternalIPs {
+		add(ip, port.Port)
+	}
+	if port.NodePort != 0 {
+		// may not have permission to list nodes
+		nodeList, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Debugf("list nodes failed: %v", err)
+		} else {
+			for _, node := range nodeList.Items {
+				for _, address := range node.Status.Addresses {
+					if address.Type == v1.NodeExternalIP || address.Type == v1.NodeInternalIP {
+						add(address.Address, port.NodePort)
+					}
+				}
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		return "", false
+	}
+	secret, err := c.clientset.CoreV1().Secrets(c.Namespace).Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+	if err != nil || len(secret.Data[config.TLSCertKey]) == 0 {
+		log.Debugf("get certificate of %s failed: %v", config.ConfigMapPodTrafficManager, err)
+		return "", false
+	}
+	pin, err := core.QUICCertificatePin(secret.Data[config.TLSCertKey])
+	if err != nil {
+		log.Debugf("invalid certificate of %s: %v", config.ConfigMapPodTrafficManager, err)
+		return "", false
+	}
+
+	var result = make(chan string, len(addrs))
+	var wg sync.WaitGroup
+	for _, addr := range addrs {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			if err := core.ProbeQUIC(ctx, addr, pin); err != nil {
+				log.Debugf("probe quic %s failed: %v", addr, err)
+				return
+			}
+			result <- addr
+		}(addr)
+	}
+	go func() {
+		wg.Wait()
+		close(result)
+	}()
+	addr, ok := <-result
+	if !ok {
+		return "", false
+	}
+	// certificate is pinned per chain node, every connection pins certificate of its own traffic manager
+	return fmt.Sprintf("quic://%s?pin=%s", addr, pin), true
+}
+
 // detect pod is delete event, if pod is deleted, needs to redo port-forward immediately
 func (c *ConnectOptions) portForward(ctx context.Context, portPair []string) error {
 	var readyChan = make(chan struct{}, 1)
@@ -283,6 +433,8 @@ func (c *ConnectOptions) portForward(ctx context.Context, portPair []string) err
 	podInterface := c.clientset.CoreV1().Pods(c.Namespace)
 	go func() {
 		var first = pointer.Bool(true)
+		// session of client lives in one replica, reconnect to it if it is still running
+		var lastPod string
 		for {
 			func() {
 				podList, err := c.GetRunningPodList(ctx)
@@ -295,7 +447,14 @@ func (c *ConnectOptions) portForward(ctx context.Context, portPair []string) err
 				if !*first {
 					readyChan = nil
 				}
-				podName := podList[0].GetName()
+				// traffic manager may have several replicas which share routes, spread clients between them
+				podName := podList[rand.Intn(len(podList))].GetName()
+				for _, pod := range podList {
+					if pod.GetName() == lastPod {
+						podName = lastPod
+					}
+				}
+				lastPod = podName
 				// if port-forward occurs error, check pod is deleted or not, speed up fail
 				utilruntime.ErrorHandlers = []func(error){func(err error) {
 					if !strings.Contains(err.Error(), "an error occurred forwarding") {
@@ -396,17 +555,21 @@ func (c *ConnectOptions) startLocalTunServe(ctx context.Context, forwardAddress
 		return err
 	}
 
+	serveNode := fmt.Sprintf("tun:/127.0.0.1:8422?net=%s&route=%s&%s=%s",
+		c.localTunIPv4.String(),
+		strings.Join(list.UnsortedList(), ","),
+		config.ConfigKubeVPNTransportEngine,
+		string(c.Engine),
+	)
+	if c.MTU > 0 {
+		serveNode += fmt.Sprintf("&mtu=%d", c.MTU)
+	}
+	// every tun device forwards to gvisor forwarders of its own traffic manager
+	serveNode += fmt.Sprintf("&gtcp=%s&gudp=%s", url.QueryEscape(c.gvisorTCPForward), url.QueryEscape(c.gvisorUDPForward))
 	r := core.Route{
-		ServeNodes: []string{
-			fmt.Sprintf("tun:/127.0.0.1:8422?net=%s&route=%s&%s=%s",
-				c.localTunIPv4.String(),
-				strings.Join(list.UnsortedList(), ","),
-				config.ConfigKubeVPNTransportEngine,
-				string(c.Engine),
-			),
-		},
-		ChainNode: forwardAddress,
-		Retries:   5,
+		ServeNodes: []string{serveNode},
+		ChainNode:  forwardAddress,
+		Retries:    5,
 	}
 
 	log.Debugf("ipv4: %s, ipv6: %s", c.localTunIPv4.IP.String(), c.localTunIPv6.IP.String())
@@ -660,8 +823,13 @@ func (c *ConnectOptions) setupDNS(ctx context.Context, lite bool) error {
 		UseLocalDNS: c.UseLocalDNS,
 		TunName:     tunName,
 		Lite:        lite,
+		ExtraDomain: c.ExtraDomain,
+		Context:     c.GetKubeconfigContext(),
+		Primary:     !lite || c.PrimaryDNS,
 		Hosts:       c.extraHost,
 	}
+	// answer headless services, statefulset pods and external name services locally
+	c.dnsConfig.WatchLocalZone(ctx, c.clientset, c.Namespace)
 	if err = c.dnsConfig.SetupDNS(); err != nil {
 		return err
 	}
@@ -1065,6 +1233,44 @@ func (c *ConnectOptions) GetRunningPodList(ctx context.Context) ([]v1.Pod, error
 	return list.Items, nil
 }
 
+// probePathMTU probe path mtu to a pod through tunnel, packets cross tunnel and overlay network of cluster,
+// it falls back to traffic manager if no other pod is running
+func (c *ConnectOptions) probePathMTU(ctx context.Context) {
+	target := config.RouterIP
+	podList, err := c.clientset.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(v1.PodRunning)).String(),
+	})
+	if err != nil {
+		log.Debugf("list pods failed: %v", err)
+	} else {
+		for _, pod := range podList.Items {
+			if pod.Spec.HostNetwork || pod.Labels["app"] == config.ConfigMapPodTrafficManager {
+				continue
+			}
+			if ip := net.ParseIP(pod.Status.PodIP); ip != nil && ip.To4() != nil {
+				target = ip
+				break
+			}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		mtu, err := core.PathMTUs.Probe(ctx, c.localTunIPv4.IP, target, config.DefaultMTU)
+		if err == nil {
+			if mtu < config.DefaultMTU {
+				log.Infof("path mtu to %s is %d, packets larger than it will be rejected by icmp fragmentation needed", target, mtu)
+				core.PathMTUs.Set(c.localTunIPv4.IP, mtu)
+			}
+			return
+		}
+		log.Debugf("probe path mtu to %s failed: %v", target, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
+	}
+}
+
 // getCIDR
 // 1: get pod cidr
 // 2: get service cidr
@@ -1246,7 +1452,8 @@ RetryWithDNSClient:
 	client := &miekgdns.Client{Net: "udp", Timeout: time.Second * 2, SingleInflight: true}
 	for _, domain := range c.ExtraDomain {
 		var success = false
-		for _, qType := range []uint16{miekgdns.TypeA /*, miekgdns.TypeAAAA*/} {
+		// route both ipv4 and ipv6 address of domain, domain may has no ipv6 address, so empty AAAA answer is not retried
+		for _, qType := range []uint16{miekgdns.TypeA, miekgdns.TypeAAAA} {
 			var iErr = errors.New("No retry")
 			err = retry.OnError(
 				wait.Backoff{
@@ -1254,7 +1461,7 @@ RetryWithDNSClient:
 					Duration: time.Millisecond * 30,
 				},
 				func(err error) bool {
-					return err != nil
+					return err != nil && !(err == iErr && qType == miekgdns.TypeAAAA)
 				},
 				func() error {
 					var answer *miekgdns.Msg
@@ -1296,9 +1503,6 @@ RetryWithDNSClient:
 			if err != nil && err != iErr {
 				return err
 			}
-			if success {
-				break
-			}
 		}
 		if !success {
 			return fmt.Errorf("failed to resolve dns for domain %s", domain)
@@ -1537,9 +1741,152 @@ func (c *ConnectOptions) heartbeats(ctx context.Context) {
 	}
 }
 
+// renewRules renew lease of proxy rules which route to local tun ip, if this client is gone, rules will be expired
+func (c *ConnectOptions) renewRules(ctx context.Context) {
+	ticker := time.NewTicker(controlplane.RuleLeaseDuration / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.localTunIPv4 == nil {
+				continue
+			}
+			expireAt := time.Now().Add(controlplane.RuleLeaseDuration)
+			mapInterface := c.clientset.CoreV1().ConfigMaps(c.Namespace)
+			err := renewRuleLease(ctx, mapInterface, c.localTunIPv4.IP.String(), expireAt)
+			if err != nil {
+				log.Debugf("renew rules lease failed: %v", err)
+			}
+			if !trafficRuleEnabled(ctx, mapInterface) {
+				continue
+			}
+			client, err := c.factory.DynamicClient()
+			if err != nil {
+				log.Debugf("renew traffic rules lease failed: %v", err)
+				continue
+			}
+			err = renewTrafficRule(ctx, client, c.Namespace, c.localTunIPv4.IP.String(), expireAt)
+			if err != nil {
+				log.Debugf("renew traffic rules lease failed: %v", err)
+			}
+		}
+	}
+}
+
+// renewTunnelKey renew lifetime of tunnel key, if this client is gone, key will be expired
+func (c *ConnectOptions) renewTunnelKey(ctx context.Context) {
+	ticker := time.NewTicker(TunnelKeyLifetime / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c.localTunIPv4 == nil || len(c.tunnelKey) == 0 {
+				continue
+			}
+			err := c.tunnelKeyProof(ctx, http.MethodPut)
+			if err == nil {
+				continue
+			}
+			if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+				log.Debugf("renew tunnel key failed: %v", err)
+				continue
+			}
+			log.Warnf("tunnel key is rejected by traffic manager, request a new one: %v", err)
+			if err = c.reissueTunnelKey(ctx); err != nil {
+				log.Errorf("request tunnel key failed: %v", err)
+			} else {
+				log.Infof("tunnel key is issued again")
+			}
+		}
+	}
+}
+
+// withQuery append query to address of chain node
+func withQuery(addr string, query ...string) string {
+	if len(query) == 0 {
+		return addr
+	}
+	if strings.Contains(addr, "?") {
+		return addr + "&" + strings.Join(query, "&")
+	}
+	return addr + "?" + strings.Join(query, "&")
+}
+
+// forwardAddr chain node of traffic manager, it is reached by port-forward, or through ingress by websocket,
+// or by http CONNECT through HTTPS_PROXY if port-forward is blocked
+func (c *ConnectOptions) forwardAddr(ctx context.Context) (string, error) {
+	switch c.Transport {
+	case config.TransportWebSocket:
+		u, err := url.Parse(c.TransportAddr)
+		if err != nil || (u.Scheme != "ws" && u.Scheme != "wss") {
+			return "", fmt.Errorf("transport address of websocket must be ws://host/path or wss://host/path, but got %q", c.TransportAddr)
+		}
+		c.gvisorTCPForward = core.WebSocketForwardAddr(c.TransportAddr, "gtcp")
+		c.gvisorUDPForward = core.WebSocketForwardAddr(c.TransportAddr, "gudp")
+		return c.TransportAddr, nil
+	case config.TransportHTTPConnect:
+		host, _, err := net.SplitHostPort(c.TransportAddr)
+		if err != nil {
+			return "", fmt.Errorf("transport address of http connect must be host:port, but got %q", c.TransportAddr)
+		}
+		// gvisor forwarders are exposed on same host
+		c.gvisorTCPForward = "connect://" + net.JoinHostPort(host, "10801")
+		c.gvisorUDPForward = "connect://" + net.JoinHostPort(host, "10802")
+		return "connect://" + c.TransportAddr, nil
+	case config.TransportPortForward, "":
+	default:
+		return "", fmt.Errorf("not support transport %s", c.Transport)
+	}
+
+	var err error
+	var rawTCPForwardPort, gvisorTCPForwardPort, gvisorUDPForwardPort int
+	rawTCPForwardPort, err = util.GetAvailableTCPPortOrDie()
+	if err != nil {
+		return "", err
+	}
+	gvisorTCPForwardPort, err = util.GetAvailableTCPPortOrDie()
+	if err != nil {
+		return "", err
+	}
+	gvisorUDPForwardPort, err = util.GetAvailableTCPPortOrDie()
+	if err != nil {
+		return "", err
+	}
+	if err = c.portForward(ctx, []string{
+		fmt.Sprintf("%d:10800", rawTCPForwardPort),
+		fmt.Sprintf("%d:10801", gvisorTCPForwardPort),
+		fmt.Sprintf("%d:10802", gvisorUDPForwardPort),
+	}); err != nil {
+		return "", err
+	}
+	forward := fmt.Sprintf("tcp://127.0.0.1:%d", rawTCPForwardPort)
+	if c.Streams > 1 {
+		forward = fmt.Sprintf("mtcp://127.0.0.1:%d?streams=%d", rawTCPForwardPort, c.Streams)
+	}
+	// prefer quic if traffic manager is directly reachable, otherwise fall back to port-forward
+	if addr, ok := c.probeQUIC(ctx); ok {
+		log.Infof("traffic manager is reachable by %s", addr)
+		forward = addr
+	}
+	c.gvisorTCPForward = fmt.Sprintf("tcp://127.0.0.1:%d", gvisorTCPForwardPort)
+	c.gvisorUDPForward = fmt.Sprintf("tcp://127.0.0.1:%d", gvisorUDPForwardPort)
+	return forward, nil
+}
+
 func (c *ConnectOptions) Equal(a *ConnectOptions) bool {
 	return c.UseLocalDNS == a.UseLocalDNS &&
 		c.Engine == a.Engine &&
+		c.Streams == a.Streams &&
+		c.MTU == a.MTU &&
+		c.Transport == a.Transport &&
+		c.TransportAddr == a.TransportAddr &&
+		c.PrimaryDNS == a.PrimaryDNS &&
 		reflect.DeepEqual(c.ExtraDomain, a.ExtraDomain) &&
 		reflect.DeepEqual(c.ExtraCIDR, a.ExtraCIDR)
 }
@@ -1570,6 +1917,15 @@ func (c *ConnectOptions) GetKubeconfigCluster() string {
 	return ""
 }
 
+// GetKubeconfigContext current context of kubeconfig, names qualified by it are resolved by this cluster
+func (c *ConnectOptions) GetKubeconfigContext() string {
+	rawConfig, err := c.GetFactory().ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return ""
+	}
+	return rawConfig.CurrentContext
+}
+
 func (c *ConnectOptions) AddRolloutFunc(f func() error) {
 	c.rollbackFuncList = append(c.rollbackFuncList, f)
 }
@@ -19,15 +19,18 @@ import (
 )
 
 type DHCPManager struct {
-	client    corev1.ConfigMapInterface
+	client corev1.ConfigMapInterface
+	// secret saves tunnel keys, it is nil on client side, only traffic manager issue tunnel keys
+	secret    corev1.SecretInterface
 	cidr      *net.IPNet
 	cidr6     *net.IPNet
 	namespace string
 }
 
-func NewDHCPManager(client corev1.ConfigMapInterface, namespace string) *DHCPManager {
+func NewDHCPManager(client corev1.ConfigMapInterface, secret corev1.SecretInterface, namespace string) *DHCPManager {
 	return &DHCPManager{
 		client:    client,
+		secret:    secret,
 		namespace: namespace,
 		cidr:      &net.IPNet{IP: config.RouterIP, Mask: config.CIDR.Mask},
 		cidr6:     &net.IPNet{IP: config.RouterIP6, Mask: config.CIDR6.Mask},
@@ -166,7 +169,7 @@ func (d *DHCPManager) ReleaseIP(ctx context.Context, ips ...net.IP) error {
 	if len(ips) == 0 {
 		return nil
 	}
-	return d.updateDHCPConfigMap(ctx, func(ipv4 *ipallocator.Range, ipv6 *ipallocator.Range) error {
+	err := d.updateDHCPConfigMap(ctx, func(ipv4 *ipallocator.Range, ipv6 *ipallocator.Range) error {
 		for _, ip := range ips {
 			var use *ipallocator.Range
 			if ip.To4() != nil {
@@ -180,6 +183,10 @@ func (d *DHCPManager) ReleaseIP(ctx context.Context, ips ...net.IP) error {
 		}
 		return nil
 	})
+	if err != nil || d.secret == nil {
+		return err
+	}
+	return d.revokeTunnelKey(ctx, ips...)
 }
 
 func (d *DHCPManager) updateDHCPConfigMap(ctx context.Context, f func(ipv4 *ipallocator.Range, ipv6 *ipallocator.Range) error) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -18,7 +19,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	pkgresource "k8s.io/cli-runtime/pkg/resource"
 	runtimeresource "k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
 	v12 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/yaml"
 
@@ -31,7 +34,7 @@ import (
 // https://istio.io/latest/docs/ops/deployment/requirements/#ports-used-by-istio
 
 // InjectVPNAndEnvoySidecar patch a sidecar, using iptables to do port-forward let this pod decide should go to 233.254.254.100 or request to 127.0.0.1
-func InjectVPNAndEnvoySidecar(ctx1 context.Context, factory cmdutil.Factory, clientset v12.ConfigMapInterface, namespace, workload string, c util.PodRouteConfig, headers map[string]string) (err error) {
+func InjectVPNAndEnvoySidecar(ctx1 context.Context, factory cmdutil.Factory, clientset v12.ConfigMapInterface, namespace, workload string, c util.PodRouteConfig, rule *controlplane.Rule) (err error) {
 	var object *runtimeresource.Info
 	object, err = util.GetUnstructuredObject(factory, namespace, workload)
 	if err != nil {
@@ -54,7 +57,16 @@ func InjectVPNAndEnvoySidecar(ctx1 context.Context, factory cmdutil.Factory, cli
 	}
 	nodeID := fmt.Sprintf("%s.%s", object.Mapping.Resource.GroupResource().String(), object.Name)
 
-	err = addEnvoyConfig(clientset, nodeID, c, headers, port)
+	if trafficRuleEnabled(ctx1, clientset) {
+		var client dynamic.Interface
+		client, err = factory.DynamicClient()
+		if err != nil {
+			return err
+		}
+		err = addTrafficRule(ctx1, client, namespace, nodeID, rule, port)
+	} else {
+		err = addEnvoyConfig(clientset, nodeID, rule, port)
+	}
 	if err != nil {
 		log.Errorf("add envoy config error: %v", err)
 		return err
@@ -136,6 +148,21 @@ func UnPatchContainer(factory cmdutil.Factory, mapInterface v12.ConfigMapInterfa
 		log.Errorf("remove envoy config error: %v", err)
 		return err
 	}
+	// rules may be saved in configmap by old version client, so remove both
+	if trafficRuleEnabled(context.Background(), mapInterface) {
+		var client dynamic.Interface
+		client, err = factory.DynamicClient()
+		if err != nil {
+			return err
+		}
+		var emptyTrafficRule bool
+		emptyTrafficRule, err = removeTrafficRule(context.Background(), client, namespace, nodeID, localTunIPv4)
+		if err != nil {
+			log.Errorf("remove traffic rule error: %v", err)
+			return err
+		}
+		empty = empty && emptyTrafficRule
+	}
 
 	mesh.RemoveContainers(templateSpec)
 	if u.GetAnnotations() != nil && u.GetAnnotations()[config.KubeVPNRestorePatchKey] != "" {
@@ -188,11 +215,21 @@ func UnPatchContainer(factory cmdutil.Factory, mapInterface v12.ConfigMapInterfa
 	return err
 }
 
-func addEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, tunIP util.PodRouteConfig, headers map[string]string, port []v1.ContainerPort) error {
+// addEnvoyConfig add rule to envoy config, lease of rule is saved in its own key, so renewing it not rewrite envoy config
+func addEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, rule *controlplane.Rule, port []v1.ContainerPort) error {
 	configMap, err := mapInterface.Get(context.Background(), config.ConfigMapPodTrafficManager, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	if rule.ExpireAt != nil {
+		configMap.Data[controlplane.RuleLeaseKey(rule.LocalTunIPv4)] = rule.ExpireAt.UTC().Format(time.RFC3339)
+		r := *rule
+		r.ExpireAt = nil
+		rule = &r
+	}
 	var v = make([]*controlplane.Virtual, 0)
 	if str, ok := configMap.Data[config.KeyEnvoy]; ok {
 		if err = yaml.Unmarshal([]byte(str), &v); err != nil {
@@ -210,27 +247,26 @@ func addEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, tunIP ut
 		v = append(v, &controlplane.Virtual{
 			Uid:   nodeID,
 			Ports: port,
-			Rules: []*controlplane.Rule{{
-				Headers:      headers,
-				LocalTunIPv4: tunIP.LocalTunIPv4,
-				LocalTunIPv6: tunIP.LocalTunIPv6,
-			}},
+			Rules: []*controlplane.Rule{rule},
 		})
 	} else {
 		var found bool
-		for j, rule := range v[index].Rules {
-			if rule.LocalTunIPv4 == tunIP.LocalTunIPv4 &&
-				rule.LocalTunIPv6 == tunIP.LocalTunIPv6 {
+		for j, r := range v[index].Rules {
+			if r.LocalTunIPv4 == rule.LocalTunIPv4 &&
+				r.LocalTunIPv6 == rule.LocalTunIPv6 {
 				found = true
-				v[index].Rules[j].Headers = util.Merge[string, string](v[index].Rules[j].Headers, headers)
+				v[index].Rules[j].Headers = util.Merge[string, string](v[index].Rules[j].Headers, rule.Headers)
+				v[index].Rules[j].Weight = rule.Weight
+				v[index].Rules[j].Mirror = rule.Mirror
+				v[index].Rules[j].Fallback = rule.Fallback
+				v[index].Rules[j].SourceLabels = rule.SourceLabels
+				v[index].Rules[j].SourceIPs = rule.SourceIPs
+				v[index].Rules[j].ServerNames = rule.ServerNames
+				v[index].Rules[j].ExpireAt = nil
 			}
 		}
 		if !found {
-			v[index].Rules = append(v[index].Rules, &controlplane.Rule{
-				Headers:      headers,
-				LocalTunIPv4: tunIP.LocalTunIPv4,
-				LocalTunIPv6: tunIP.LocalTunIPv6,
-			})
+			v[index].Rules = append(v[index].Rules, rule)
 			if v[index].Ports == nil {
 				v[index].Ports = port
 			}
@@ -246,6 +282,27 @@ func addEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, tunIP ut
 	return err
 }
 
+// renewRuleLease renew lease of rules which route to localTunIPv4, only lease key is patched, envoy config is not
+// touched, do nothing if no lease found
+func renewRuleLease(ctx context.Context, mapInterface v12.ConfigMapInterface, localTunIPv4 string, expireAt time.Time) error {
+	configMap, err := mapInterface.Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	key := controlplane.RuleLeaseKey(localTunIPv4)
+	if _, ok := configMap.Data[key]; !ok {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{key: expireAt.UTC().Format(time.RFC3339)},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = mapInterface.Patch(ctx, config.ConfigMapPodTrafficManager, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
 func removeEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, localTunIPv4 string) (bool, error) {
 	configMap, err := mapInterface.Get(context.Background(), config.ConfigMapPodTrafficManager, metav1.GetOptions{})
 	if k8serrors.IsNotFound(err) {
@@ -272,13 +329,16 @@ func removeEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, local
 			}
 		}
 	}
-	var empty bool
+	var empty = true
 	// remove default
 	for i := 0; i < len(v); i++ {
-		if nodeID == v[i].Uid && len(v[i].Rules) == 0 {
+		if nodeID == v[i].Uid {
+			if len(v[i].Rules) != 0 {
+				empty = false
+				continue
+			}
 			v = append(v[:i], v[i+1:]...)
 			i--
-			empty = true
 		}
 	}
 	var bytes []byte
@@ -287,10 +347,129 @@ func removeEnvoyConfig(mapInterface v12.ConfigMapInterface, nodeID string, local
 		return false, err
 	}
 	configMap.Data[config.KeyEnvoy] = string(bytes)
+	delete(configMap.Data, controlplane.RuleLeaseKey(localTunIPv4))
 	_, err = mapInterface.Update(context.Background(), configMap, metav1.UpdateOptions{})
 	return empty, err
 }
 
+// trafficRuleEnabled control plane watch crd TrafficRule or not, if not, save proxy rules in configmap
+func trafficRuleEnabled(ctx context.Context, mapInterface v12.ConfigMapInterface) bool {
+	configMap, err := mapInterface.Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return configMap.Data[config.KeyTrafficRule] == "true"
+}
+
+func addTrafficRule(ctx context.Context, client dynamic.Interface, namespace, nodeID string, rule *controlplane.Rule, port []v1.ContainerPort) error {
+	owner, _ := os.Hostname()
+	resourceInterface := client.Resource(controlplane.TrafficRuleGroupVersionResource).Namespace(namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		u, err := resourceInterface.Get(ctx, controlplane.TrafficRuleName(nodeID, rule.LocalTunIPv4), metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			u, err = controlplane.NewTrafficRule(namespace, nodeID, owner, rule, port).ToUnstructured()
+			if err != nil {
+				return err
+			}
+			_, err = resourceInterface.Create(ctx, u, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		t, err := controlplane.FromUnstructured(u)
+		if err != nil {
+			return err
+		}
+		// same as configmap, merge headers of same client
+		r := *rule
+		if t.Spec.Rule != nil {
+			r.Headers = util.Merge[string, string](t.Spec.Rule.Headers, rule.Headers)
+		}
+		t.Spec.Rule = &r
+		t.Spec.Ports = port
+		t.Spec.Owner = owner
+		u, err = t.ToUnstructured()
+		if err != nil {
+			return err
+		}
+		_, err = resourceInterface.Update(ctx, u, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// removeTrafficRule returns true if workload has no traffic rule anymore
+func removeTrafficRule(ctx context.Context, client dynamic.Interface, namespace, nodeID string, localTunIPv4 string) (bool, error) {
+	resourceInterface := client.Resource(controlplane.TrafficRuleGroupVersionResource).Namespace(namespace)
+	err := resourceInterface.Delete(ctx, controlplane.TrafficRuleName(nodeID, localTunIPv4), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	rules, err := controlplane.ListTrafficRules(ctx, client, namespace)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range rules {
+		if t.Spec.Workload == nodeID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// renewTrafficRule renew lease of traffic rules which route to localTunIPv4
+func renewTrafficRule(ctx context.Context, client dynamic.Interface, namespace string, localTunIPv4 string, expireAt time.Time) error {
+	rules, err := controlplane.ListTrafficRules(ctx, client, namespace)
+	if err != nil {
+		return err
+	}
+	resourceInterface := client.Resource(controlplane.TrafficRuleGroupVersionResource).Namespace(namespace)
+	for _, t := range rules {
+		if t.Spec.Rule == nil || t.Spec.Rule.LocalTunIPv4 != localTunIPv4 {
+			continue
+		}
+		t.Spec.Rule.ExpireAt = &metav1.Time{Time: expireAt}
+		u, err := t.ToUnstructured()
+		if err != nil {
+			return err
+		}
+		// conflict is fine, renew it next time, lease is much longer than renew interval
+		_, err = resourceInterface.Update(ctx, u, metav1.UpdateOptions{})
+		if k8serrors.IsConflict(err) {
+			log.Debugf("renew lease of %s conflict, renew it next time: %v", t.Name, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetProxyRules proxy rules in configmap and crd TrafficRule
+func GetProxyRules(ctx context.Context, mapInterface v12.ConfigMapInterface, client dynamic.Interface, namespace string) ([]*controlplane.Virtual, error) {
+	var v = make([]*controlplane.Virtual, 0)
+	configMap, err := mapInterface.Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	if configMap != nil && configMap.Data != nil {
+		if str, ok := configMap.Data[config.KeyEnvoy]; ok {
+			if err = yaml.Unmarshal([]byte(str), &v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if client == nil {
+		return v, nil
+	}
+	rules, err := controlplane.ListTrafficRules(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return controlplane.MergeTrafficRules(v, rules), nil
+}
+
 func contains(a map[string]string, sub map[string]string) bool {
 	for k, v := range sub {
 		if a[k] != v {
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/core"
+)
+
+// PeerPort replicas of traffic manager forward packets to each other on this port
+const PeerPort = "10804"
+
+// parsePeerRoutes map[tun ip]peer address of replica
+func parsePeerRoutes(cm *v1.ConfigMap) (map[string]string, error) {
+	var routes = map[string]string{}
+	if cm == nil || len(cm.Data[config.KeyPeerRoute]) == 0 {
+		return routes, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Data[config.KeyPeerRoute]), &routes); err != nil {
+		return nil, fmt.Errorf("failed to parse peer routes, err: %v", err)
+	}
+	return routes, nil
+}
+
+// SharePeerRoutes every replica of traffic manager publishes tun ip of its clients to configmap, and watch routes of
+// other replicas, so packets between clients which connect to different replicas are forwarded to each other
+func SharePeerRoutes(ctx context.Context, clientset kubernetes.Interface, namespace string, watcher *TrafficManagerWatcher) error {
+	podIP := os.Getenv(config.EnvPodIP)
+	if net.ParseIP(podIP) == nil {
+		return fmt.Errorf("can not get pod ip from env %s", config.EnvPodIP)
+	}
+	self := net.JoinHostPort(podIP, PeerPort)
+	core.PeerRoutes.Enable(self)
+	err := watcher.OnConfigMap(func(cm *v1.ConfigMap) {
+		routes, err := parsePeerRoutes(cm)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		core.PeerRoutes.Update(routes)
+	})
+	if err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(time.Second * 30)
+		defer ticker.Stop()
+		for {
+			// first publish also clean up routes of this pod ip which are left by previous pod
+			if err := publishPeerRoutes(ctx, clientset, namespace, self); err != nil {
+				log.Errorf("publish peer routes failed: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-core.PeerRoutes.Changed():
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// runningPeers peer address of traffic manager replicas which are running
+func runningPeers(ctx context.Context, clientset kubernetes.Interface, namespace string) (map[string]struct{}, error) {
+	list, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fields.OneTermEqualSelector("app", config.ConfigMapPodTrafficManager).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var peers = map[string]struct{}{}
+	for _, pod := range list.Items {
+		if pod.GetDeletionTimestamp() == nil && pod.Status.Phase == v1.PodRunning && pod.Status.PodIP != "" {
+			peers[net.JoinHostPort(pod.Status.PodIP, PeerPort)] = struct{}{}
+		}
+	}
+	return peers, nil
+}
+
+// publishPeerRoutes route local clients to self, remove routes to self which clients are gone, and routes to
+// replicas which are not running, eg: pod is recreated with new ip
+func publishPeerRoutes(ctx context.Context, clientset kubernetes.Interface, namespace string, self string) error {
+	peers, err := runningPeers(ctx, clientset, namespace)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		routes, err := parsePeerRoutes(cm)
+		if err != nil {
+			return err
+		}
+		var changed bool
+		var local = map[string]struct{}{}
+		for _, ip := range core.PeerRoutes.Local() {
+			local[ip] = struct{}{}
+			if routes[ip] != self {
+				routes[ip] = self
+				changed = true
+			}
+		}
+		for ip, addr := range routes {
+			if _, ok := local[ip]; addr == self && !ok {
+				delete(routes, ip)
+				changed = true
+			} else if _, running := peers[addr]; addr != self && !running {
+				delete(routes, ip)
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		bytes, err := json.Marshal(routes)
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[config.KeyPeerRoute] = string(bytes)
+		_, err = clientset.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/core"
+)
+
+// parseRateLimits nil means no limit
+func parseRateLimits(cm *v1.ConfigMap) (*core.RateLimitConfig, error) {
+	if cm == nil || len(cm.Data[config.KeyRateLimit]) == 0 {
+		return nil, nil
+	}
+	var limits core.RateLimitConfig
+	if err := json.Unmarshal([]byte(cm.Data[config.KeyRateLimit]), &limits); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limits, err: %v", err)
+	}
+	return &limits, nil
+}
+
+// WatchRateLimits traffic manager watch per client bandwidth limits in configmap, cluster admin can cap clients by
+// editing key RATE_LIMIT of configmap without restarting traffic manager
+func WatchRateLimits(watcher *TrafficManagerWatcher) error {
+	return watcher.OnConfigMap(func(cm *v1.ConfigMap) {
+		limits, err := parseRateLimits(cm)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		core.RateLimits.Update(limits)
+		if limits != nil {
+			log.Debugf("update rate limits, default: %s, clients: %d", limits.Default.Rate.String(), len(limits.Clients))
+		}
+	})
+}
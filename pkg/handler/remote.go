@@ -16,11 +16,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sjson "k8s.io/apimachinery/pkg/util/json"
@@ -35,13 +37,17 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/controlplane"
 	"github.com/wencaiwulue/kubevpn/pkg/exchange"
 	"github.com/wencaiwulue/kubevpn/pkg/util"
 )
 
-func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *kubernetes.Clientset, namespace string) (err error) {
+func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *kubernetes.Clientset, namespace string, replicas int32) (err error) {
 	innerIpv4CIDR := net.IPNet{IP: config.RouterIP, Mask: config.CIDR.Mask}
 	innerIpv6CIDR := net.IPNet{IP: config.RouterIP6, Mask: config.CIDR6.Mask}
+	if replicas <= 0 {
+		replicas = config.DefaultReplicas
+	}
 
 	service, err := clientset.CoreV1().Services(namespace).Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
 	if err == nil {
@@ -89,6 +95,11 @@ func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *
 		return err
 	}
 
+	// crd is optional, if no permission to create it, save proxy rules in configmap
+	if err = createTrafficRuleCRD(ctx, factory, namespace); err != nil {
+		log.Warnf("create crd %s error: %v, proxy rules will be saved in configmap", controlplane.TrafficRulePlural, err)
+	}
+
 	// 2) create serviceAccount
 	log.Infof("create serviceAccount %s", config.ConfigMapPodTrafficManager)
 	_, err = clientset.CoreV1().ServiceAccounts(namespace).Create(ctx, &v1.ServiceAccount{
@@ -115,6 +126,16 @@ func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *
 			APIGroups:     []string{""},
 			Resources:     []string{"configmaps", "secrets"},
 			ResourceNames: []string{config.ConfigMapPodTrafficManager},
+		}, {
+			// control plane resolve rule source labels to pod ip
+			Verbs:     []string{"get", "list", "watch"},
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+		}, {
+			// control plane watch proxy rules
+			Verbs:     []string{"get", "list", "watch"},
+			APIGroups: []string{controlplane.TrafficRuleGroup},
+			Resources: []string{controlplane.TrafficRulePlural},
 		}},
 	}, metav1.CreateOptions{})
 	if err != nil {
@@ -150,6 +171,10 @@ func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *
 	log.Infof("create service %s", config.ConfigMapPodTrafficManager)
 	udp8422 := "8422-for-udp"
 	tcp10800 := "10800-for-tcp"
+	udp10803 := "10803-for-quic"
+	tcp10804 := "10804-for-peer"
+	tcp10805 := "10805-for-websocket"
+	tcp9100 := "9100-for-metrics"
 	tcp9002 := "9002-for-envoy"
 	tcp80 := "80-for-webhook"
 	_, err = clientset.CoreV1().Services(namespace).Create(ctx, &v1.Service{
@@ -168,6 +193,17 @@ func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *
 				Protocol:   v1.ProtocolTCP,
 				Port:       10800,
 				TargetPort: intstr.FromInt(10800),
+			}, {
+				Name:       udp10803,
+				Protocol:   v1.ProtocolUDP,
+				Port:       10803,
+				TargetPort: intstr.FromInt(10803),
+			}, {
+				// expose by ingress for clients which can not port-forward, eg: behind http proxy
+				Name:       tcp10805,
+				Protocol:   v1.ProtocolTCP,
+				Port:       10805,
+				TargetPort: intstr.FromInt(10805),
 			}, {
 				Name:       tcp9002,
 				Protocol:   v1.ProtocolTCP,
@@ -246,33 +282,34 @@ func createOutboundPod(ctx context.Context, factory cmdutil.Factory, clientset *
 			Namespace: namespace,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: pointer.Int32(1),
+			// routes of clients are shared between replicas, so deployment can be scaled out
+			Replicas: pointer.Int32(replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{"app": config.ConfigMapPodTrafficManager},
 			},
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"app": config.ConfigMapPodTrafficManager},
+					Annotations: map[string]string{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "9100",
+					},
 				},
 				Spec: v1.PodSpec{
 					ServiceAccountName: config.ConfigMapPodTrafficManager,
-					Volumes: []v1.Volume{{
-						Name: config.VolumeEnvoyConfig,
-						VolumeSource: v1.VolumeSource{
-							ConfigMap: &v1.ConfigMapVolumeSource{
-								LocalObjectReference: v1.LocalObjectReference{
-									Name: config.ConfigMapPodTrafficManager,
-								},
-								Items: []v1.KeyToPath{
-									{
-										Key:  config.KeyEnvoy,
-										Path: "envoy-config.yaml",
+					Affinity: &v1.Affinity{
+						PodAntiAffinity: &v1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{{
+								Weight: 100,
+								PodAffinityTerm: v1.PodAffinityTerm{
+									LabelSelector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"app": config.ConfigMapPodTrafficManager},
 									},
+									TopologyKey: "kubernetes.io/hostname",
 								},
-								Optional: pointer.Bool(false),
-							},
+							}},
 						},
-					}},
+					},
 					Containers: []v1.Container{
 						{
 							Name:    config.ContainerSidecarVPN,
@@ -291,16 +328,11 @@ iptables -P FORWARD ACCEPT
 ip6tables -P FORWARD ACCEPT
 iptables -t nat -A POSTROUTING -s ${CIDR4} -o eth0 -j MASQUERADE
 ip6tables -t nat -A POSTROUTING -s ${CIDR6} -o eth0 -j MASQUERADE
-kubevpn serve -L "tcp://:10800" -L "tun://:8422?net=${TunIPv4}" -L "gtcp://:10801" -L "gudp://:10802" --debug=true`,
+kubevpn serve -L "tcp://:10800" -L "tun://:8422?net=${TunIPv4}" -L "gtcp://:10801" -L "gudp://:10802" -L "quic://:10803" -L "peer://:10804" -L "ws://:10805" --authenticate=true --rate-limit=true --share-routes=true --metrics-addr=:9100 --capture-addr=127.0.0.1:9101 --debug=true`,
 							},
-							EnvFrom: []v1.EnvFromSource{{
-								SecretRef: &v1.SecretEnvSource{
-									LocalObjectReference: v1.LocalObjectReference{
-										Name: config.ConfigMapPodTrafficManager,
-									},
-								},
-							}},
 							Env: []v1.EnvVar{
+								util.EnvFromTrafficManagerSecret(config.TLSCertKey),
+								util.EnvFromTrafficManagerSecret(config.TLSPrivateKeyKey),
 								{
 									Name:  "CIDR4",
 									Value: config.CIDR.String(),
@@ -317,6 +349,14 @@ kubevpn serve -L "tcp://:10800" -L "tun://:8422?net=${TunIPv4}" -L "gtcp://:1080
 									Name:  config.EnvInboundPodTunIPv6,
 									Value: innerIpv6CIDR.String(),
 								},
+								{
+									Name: config.EnvPodIP,
+									ValueFrom: &v1.EnvVarSource{
+										FieldRef: &v1.ObjectFieldSelector{
+											FieldPath: "status.podIP",
+										},
+									},
+								},
 							},
 							Ports: []v1.ContainerPort{{
 								Name:          udp8422,
@@ -326,6 +366,22 @@ kubevpn serve -L "tcp://:10800" -L "tun://:8422?net=${TunIPv4}" -L "gtcp://:1080
 								Name:          tcp10800,
 								ContainerPort: 10800,
 								Protocol:      v1.ProtocolTCP,
+							}, {
+								Name:          udp10803,
+								ContainerPort: 10803,
+								Protocol:      v1.ProtocolUDP,
+							}, {
+								Name:          tcp10804,
+								ContainerPort: 10804,
+								Protocol:      v1.ProtocolTCP,
+							}, {
+								Name:          tcp10805,
+								ContainerPort: 10805,
+								Protocol:      v1.ProtocolTCP,
+							}, {
+								Name:          tcp9100,
+								ContainerPort: 9100,
+								Protocol:      v1.ProtocolTCP,
 							}},
 							Resources:       ResourcesContainerVPN,
 							ImagePullPolicy: v1.PullIfNotPresent,
@@ -344,19 +400,13 @@ kubevpn serve -L "tcp://:10800" -L "tun://:8422?net=${TunIPv4}" -L "gtcp://:1080
 							Name:    config.ContainerSidecarControlPlane,
 							Image:   config.Image,
 							Command: []string{"kubevpn"},
-							Args:    []string{"control-plane", "--watchDirectoryFilename", "/etc/envoy/envoy-config.yaml"},
+							// watch configmap directly, not mount it as volume
+							Args: []string{"control-plane"},
 							Ports: []v1.ContainerPort{{
 								Name:          tcp9002,
 								ContainerPort: 9002,
 								Protocol:      v1.ProtocolTCP,
 							}},
-							VolumeMounts: []v1.VolumeMount{
-								{
-									Name:      config.VolumeEnvoyConfig,
-									ReadOnly:  true,
-									MountPath: "/etc/envoy",
-								},
-							},
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Resources:       Resources,
 						},
@@ -370,14 +420,10 @@ kubevpn serve -L "tcp://:10800" -L "tun://:8422?net=${TunIPv4}" -L "gtcp://:1080
 								ContainerPort: 80,
 								Protocol:      v1.ProtocolTCP,
 							}},
-							EnvFrom: []v1.EnvFromSource{{
-								SecretRef: &v1.SecretEnvSource{
-									LocalObjectReference: v1.LocalObjectReference{
-										Name: config.ConfigMapPodTrafficManager,
-									},
-								},
-							}},
-							Env:             []v1.EnvVar{},
+							Env: []v1.EnvVar{
+								util.EnvFromTrafficManagerSecret(config.TLSCertKey),
+								util.EnvFromTrafficManagerSecret(config.TLSPrivateKeyKey),
+							},
 							ImagePullPolicy: v1.PullIfNotPresent,
 							Resources:       Resources,
 						},
@@ -773,3 +819,24 @@ func fromPatchToProbe(spec *v1.PodTemplateSpec, path []string, patch []P) {
 		}
 	}
 }
+
+// createTrafficRuleCRD create crd TrafficRule if not exists, and wait for it can be used
+func createTrafficRuleCRD(ctx context.Context, factory cmdutil.Factory, namespace string) error {
+	client, err := factory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(controlplane.TrafficRuleCRD())
+	if err != nil {
+		return err
+	}
+	crdResource := apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions")
+	_, err = client.Resource(crdResource).Create(ctx, &unstructured.Unstructured{Object: u}, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return wait.PollImmediate(time.Second, time.Second*10, func() (bool, error) {
+		_, err = client.Resource(controlplane.TrafficRuleGroupVersionResource).Namespace(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+		return err == nil, nil
+	})
+}
@@ -7,17 +7,13 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
 	log "github.com/sirupsen/logrus"
-	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/yaml"
 
 	"github.com/wencaiwulue/kubevpn/pkg/config"
 	"github.com/wencaiwulue/kubevpn/pkg/controlplane"
 )
 
 // Reset
-// 1, get all proxy-resources from configmap
+// 1, get all proxy-resources from configmap and crd TrafficRule
 // 2, cleanup all containers
 func (c *ConnectOptions) Reset(ctx context.Context) error {
 	err := c.LeaveProxyResources(ctx)
@@ -47,22 +43,17 @@ func (c *ConnectOptions) LeaveProxyResources(ctx context.Context) (err error) {
 		return
 	}
 
-	mapInterface := c.clientset.CoreV1().ConfigMaps(c.Namespace)
-	var cm *corev1.ConfigMap
-	cm, err = mapInterface.Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
-	if err != nil && !apierrors.IsNotFound(err) {
+	client, _ := c.factory.DynamicClient()
+	var v []*controlplane.Virtual
+	v, err = GetProxyRules(ctx, c.clientset.CoreV1().ConfigMaps(c.Namespace), client, c.Namespace)
+	if err != nil {
+		log.Errorf("get proxy rules error: %v", err)
 		return
 	}
-	if cm == nil || cm.Data == nil || len(cm.Data[config.KeyEnvoy]) == 0 {
+	if len(v) == 0 {
 		log.Infof("no proxy resources found")
 		return
 	}
-	var v = make([]*controlplane.Virtual, 0)
-	str := cm.Data[config.KeyEnvoy]
-	if err = yaml.Unmarshal([]byte(str), &v); err != nil {
-		log.Errorf("unmarshal envoy config error: %v", err)
-		return
-	}
 	localTunIPv4 := c.GetLocalTunIPv4()
 	for _, virtual := range v {
 		// deployments.apps.ry-server --> deployments.apps/ry-server
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
@@ -14,6 +16,16 @@ import (
 )
 
 func RentIPIfNeeded(route *core.Route) error {
+	// ip is rented by webhook, tunnel key is mounted from secret of traffic manager
+	if v := os.Getenv(config.EnvTunnelKey); v != "" {
+		var key core.TunnelKey
+		if err := json.Unmarshal([]byte(v), &key); err != nil {
+			return fmt.Errorf("can not parse tunnel key, err: %v", err)
+		}
+		if err := setTunnelKey(route, key.Token()); err != nil {
+			return fmt.Errorf("can not set tunnel key, err: %v", err)
+		}
+	}
 	if v, ok := os.LookupEnv(config.EnvInboundPodTunIPv4); ok && v == "" {
 		namespace := os.Getenv(config.EnvPodNamespace)
 		if namespace == "" {
@@ -32,11 +44,18 @@ func RentIPIfNeeded(route *core.Route) error {
 			log.Errorf("can not get ip, err: %v", err)
 			return err
 		}
-		log.Infof("rent an ip %s", strings.TrimSpace(string(ip)))
-		ips := strings.Split(string(ip), ",")
-		if len(ips) != 2 {
+		// ipv4,ipv6[,tunnel key]
+		ips := strings.Split(strings.TrimSpace(string(ip)), ",")
+		if len(ips) < 2 {
 			return fmt.Errorf("can not get ip from %s", string(ip))
 		}
+		log.Infof("rent an ip %s", strings.Join(ips[:2], ","))
+		if len(ips) > 2 {
+			if err = setTunnelKey(route, ips[2]); err != nil {
+				log.Errorf("can not set tunnel key, err: %v", err)
+				return err
+			}
+		}
 		if err = os.Setenv(config.EnvInboundPodTunIPv4, ips[0]); err != nil {
 			log.Errorf("can not set ip, err: %v", err)
 			return err
@@ -74,3 +93,19 @@ func ReleaseIPIfNeeded() error {
 	_, err = util.DoReq(req)
 	return err
 }
+
+// setTunnelKey chain node seal packets with tunnel key
+func setTunnelKey(route *core.Route, token string) error {
+	if route.ChainNode == "" {
+		return nil
+	}
+	u, err := url.Parse(route.ChainNode)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("key", token)
+	u.RawQuery = query.Encode()
+	route.ChainNode = u.String()
+	return nil
+}
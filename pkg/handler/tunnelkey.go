@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+	"github.com/wencaiwulue/kubevpn/pkg/core"
+)
+
+// TunnelKeyLifetime key issued to client expires after lifetime, client renew it by heartbeat
+const TunnelKeyLifetime = time.Minute * 5
+
+// ErrTunnelKeyNotFound key of client is expired and removed, client needs to request a new one
+var ErrTunnelKeyNotFound = errors.New("tunnel key not found")
+
+// TunnelKeyName key of secret which saves tunnel key of client
+func TunnelKeyName(ipv4 string) string {
+	return config.KeyTunnelKey + "." + ipv4
+}
+
+func tunnelKeyClaimName(ipv4 net.IP) string {
+	return config.KeyTunnelKeyClaim + "." + ipv4.String()
+}
+
+// parseTunnelKeys map[tun ipv4]*core.TunnelKey
+func parseTunnelKeys(secret *v1.Secret) map[string]*core.TunnelKey {
+	var keys = map[string]*core.TunnelKey{}
+	if secret == nil {
+		return keys
+	}
+	for name, value := range secret.Data {
+		if !strings.HasPrefix(name, config.KeyTunnelKey+".") {
+			continue
+		}
+		var key core.TunnelKey
+		if err := json.Unmarshal(value, &key); err != nil {
+			log.Errorf("failed to parse tunnel key %s, err: %v", name, err)
+			continue
+		}
+		keys[key.IPv4] = &key
+	}
+	return keys
+}
+
+func (d *DHCPManager) updateTunnelKeys(ctx context.Context, f func(keys map[string]*core.TunnelKey) error) error {
+	if d.secret == nil {
+		return fmt.Errorf("tunnel keys are only managed by traffic manager")
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := d.secret.Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		keys := parseTunnelKeys(secret)
+		if err = f(keys); err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		for name := range secret.Data {
+			if strings.HasPrefix(name, config.KeyTunnelKey+".") {
+				delete(secret.Data, name)
+			}
+		}
+		for ip, k := range keys {
+			// clean up expired keys
+			if k.IsExpired(time.Now()) {
+				continue
+			}
+			bytes, err := json.Marshal(k)
+			if err != nil {
+				return err
+			}
+			secret.Data[TunnelKeyName(ip)] = bytes
+		}
+		_, err = d.secret.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// IssueTunnelKey traffic manager issue key to client which rent ipv4 and ipv6, lifetime 0 means key is valid until
+// ip is released, key is saved in secret as TUNNEL_KEY.<ipv4>, returns token which client use to seal packets
+func (d *DHCPManager) IssueTunnelKey(ctx context.Context, ipv4, ipv6 net.IP, lifetime time.Duration) (string, error) {
+	key, err := core.NewTunnelKey(ipv4, ipv6, lifetime)
+	if err != nil {
+		return "", err
+	}
+	err = d.updateTunnelKeys(ctx, func(keys map[string]*core.TunnelKey) error {
+		keys[key.IPv4] = key
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to issue tunnel key, err: %v", err)
+	}
+	return key.Token(), nil
+}
+
+// RenewTunnelKey extend lifetime of key issued to client, client must prove it owns the key
+func (d *DHCPManager) RenewTunnelKey(ctx context.Context, ipv4 net.IP, proof string, lifetime time.Duration) error {
+	return d.updateTunnelKeys(ctx, func(keys map[string]*core.TunnelKey) error {
+		k, ok := keys[ipv4.String()]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrTunnelKeyNotFound, ipv4)
+		}
+		if err := core.VerifyTunnelKeyProof(k, proof); err != nil {
+			return err
+		}
+		if k.ExpireAt != nil {
+			expireAt := time.Now().Add(lifetime)
+			k.ExpireAt = &expireAt
+		}
+		return nil
+	})
+}
+
+// RevokeTunnelKey client give key back once it disconnect, client must prove it owns the key
+func (d *DHCPManager) RevokeTunnelKey(ctx context.Context, ipv4 net.IP, proof string) error {
+	return d.updateTunnelKeys(ctx, func(keys map[string]*core.TunnelKey) error {
+		k, ok := keys[ipv4.String()]
+		if !ok {
+			return nil
+		}
+		if err := core.VerifyTunnelKeyProof(k, proof); err != nil {
+			return err
+		}
+		delete(keys, ipv4.String())
+		return nil
+	})
+}
+
+// revokeTunnelKey revoke key once ip is released
+func (d *DHCPManager) revokeTunnelKey(ctx context.Context, ips ...net.IP) error {
+	return d.updateTunnelKeys(ctx, func(keys map[string]*core.TunnelKey) error {
+		for _, ip := range ips {
+			delete(keys, ip.String())
+		}
+		return nil
+	})
+}
+
+// ClaimTunnelKey client save hash of random claim as TUNNEL_KEY_CLAIM.<ipv4> in configmap, traffic manager only issue
+// key to the one who knows claim, so client must have permission to write configmap, same as renting ip
+func (d *DHCPManager) ClaimTunnelKey(ctx context.Context, ipv4 net.IP) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	claim := base64.RawURLEncoding.EncodeToString(b)
+	sum := sha256.Sum256([]byte(claim))
+	patch, err := json.Marshal(map[string]interface{}{"data": map[string]string{tunnelKeyClaimName(ipv4): hex.EncodeToString(sum[:])}})
+	if err != nil {
+		return "", err
+	}
+	_, err = d.client.Patch(ctx, config.ConfigMapPodTrafficManager, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to claim tunnel key, err: %v", err)
+	}
+	return claim, nil
+}
+
+// VerifyTunnelKeyClaim traffic manager verify claim of client and remove it, so claim can only be used once
+func (d *DHCPManager) VerifyTunnelKeyClaim(ctx context.Context, ipv4 net.IP, claim string) error {
+	cm, err := d.client.Get(ctx, config.ConfigMapPodTrafficManager, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(claim))
+	expect := cm.Data[tunnelKeyClaimName(ipv4)]
+	if claim == "" || expect == "" || subtle.ConstantTimeCompare([]byte(expect), []byte(hex.EncodeToString(sum[:]))) != 1 {
+		return fmt.Errorf("invalid tunnel key claim of %s", ipv4)
+	}
+	// resource version makes sure claim is removed by only one request
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]string{"resourceVersion": cm.ResourceVersion},
+		"data":     map[string]interface{}{tunnelKeyClaimName(ipv4): nil},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = d.client.Patch(ctx, config.ConfigMapPodTrafficManager, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// WatchTunnelKeys traffic manager watch tunnel keys in secret, and only accept packets sealed by these keys
+func WatchTunnelKeys(watcher *TrafficManagerWatcher) error {
+	core.TunnelKeys.Enable()
+	return watcher.OnSecret(func(secret *v1.Secret) {
+		keys := parseTunnelKeys(secret)
+		core.TunnelKeys.Update(keys)
+		log.Debugf("update %d tunnel keys", len(keys))
+	})
+}
+
+// tunnelKeyRequest client request api of tunnel key of traffic manager through service proxy of api server
+func tunnelKeyRequest(ctx context.Context, clientset kubernetes.Interface, namespace, method string, header map[string]string) ([]byte, error) {
+	req := clientset.CoreV1().RESTClient().Verb(method).
+		Namespace(namespace).
+		Resource("services").
+		Name(fmt.Sprintf("https:%s:80", config.ConfigMapPodTrafficManager)).
+		SubResource("proxy").
+		Suffix(config.APITunnelKey)
+	for k, v := range header {
+		req.SetHeader(k, v)
+	}
+	return req.DoRaw(ctx)
+}
+
+// requestTunnelKey ask traffic manager to issue short-lived tunnel key, traffic manager only accept packets sealed by it
+func (c *ConnectOptions) requestTunnelKey(ctx context.Context) error {
+	claim, err := c.dhcp.ClaimTunnelKey(ctx, c.localTunIPv4.IP)
+	if err != nil {
+		return err
+	}
+	body, err := tunnelKeyRequest(ctx, c.clientset, c.Namespace, http.MethodPost, map[string]string{
+		config.HeaderIPv4:           c.localTunIPv4.String(),
+		config.HeaderIPv6:           c.localTunIPv6.String(),
+		config.HeaderTunnelKeyClaim: claim,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to request tunnel key, err: %v", err)
+	}
+	c.tunnelKey = strings.TrimSpace(string(body))
+	return nil
+}
+
+// reissueTunnelKey key is expired and removed by traffic manager, eg: renewal fails for a long time while laptop
+// sleeps, request a new one and replace key of tunnel and gvisor forwarders, otherwise all packets are dropped
+func (c *ConnectOptions) reissueTunnelKey(ctx context.Context) error {
+	if err := c.requestTunnelKey(ctx); err != nil {
+		return err
+	}
+	return core.RotateTunnelKey(c.tunnelKey)
+}
+
+// tunnelKeyProof renew or revoke tunnel key, method is PUT or DELETE
+func (c *ConnectOptions) tunnelKeyProof(ctx context.Context, method string) error {
+	proof, err := core.NewTunnelKeyProof(c.tunnelKey)
+	if err != nil {
+		return err
+	}
+	_, err = tunnelKeyRequest(ctx, c.clientset, c.Namespace, method, map[string]string{
+		config.HeaderIPv4:           c.localTunIPv4.String(),
+		config.HeaderTunnelKeyProof: proof,
+	})
+	return err
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
+)
+
+// TrafficManagerWatcher watch configmap and secret of traffic manager by one shared informer factory, features of
+// serve register handlers on it, so every resource is only listed and watched once
+type TrafficManagerWatcher struct {
+	factory informers.SharedInformerFactory
+}
+
+func NewTrafficManagerWatcher(clientset kubernetes.Interface, namespace string) *TrafficManagerWatcher {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, time.Minute*5,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", config.ConfigMapPodTrafficManager).String()
+		}),
+	)
+	return &TrafficManagerWatcher{factory: factory}
+}
+
+// OnConfigMap cm is nil once it is deleted
+func (w *TrafficManagerWatcher) OnConfigMap(update func(cm *v1.ConfigMap)) error {
+	return watchObject(w.factory.Core().V1().ConfigMaps().Informer(), update)
+}
+
+// OnSecret secret is nil once it is deleted
+func (w *TrafficManagerWatcher) OnSecret(update func(secret *v1.Secret)) error {
+	return watchObject(w.factory.Core().V1().Secrets().Informer(), update)
+}
+
+// Start informers which handlers are registered on, and wait for them to sync
+func (w *TrafficManagerWatcher) Start(ctx context.Context) error {
+	w.factory.Start(ctx.Done())
+	for typ, synced := range w.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync %s %s", typ, config.ConfigMapPodTrafficManager)
+		}
+	}
+	return nil
+}
+
+// watchObject call update with latest object, or zero value once it is deleted
+func watchObject[T any](informer toolscache.SharedIndexInformer, update func(obj T)) error {
+	var handle = func(obj interface{}) {
+		if o, ok := obj.(T); ok {
+			update(o)
+		}
+	}
+	_, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    handle,
+		UpdateFunc: func(oldObj, newObj interface{}) { handle(newObj) },
+		DeleteFunc: func(obj interface{}) {
+			var zero T
+			update(zero)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch %s, err: %v", config.ConfigMapPodTrafficManager, err)
+	}
+	return nil
+}
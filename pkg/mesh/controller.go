@@ -26,7 +26,6 @@ func RemoveContainers(spec *v1.PodTemplateSpec) {
 	}
 }
 
-// todo envoy support ipv6
 func AddMeshContainer(spec *v1.PodTemplateSpec, nodeId string, c util.PodRouteConfig) {
 	// remove envoy proxy containers if already exist
 	RemoveContainers(spec)
@@ -34,6 +33,9 @@ func AddMeshContainer(spec *v1.PodTemplateSpec, nodeId string, c util.PodRouteCo
 		Name:    config.ContainerSidecarVPN,
 		Image:   config.Image,
 		Command: []string{"/bin/sh", "-c"},
+		// ipv6 has no route_localnet, packet DNAT to [::1] from other interface will be dropped as martian,
+		// so use REDIRECT to let envoy listener [::]:15006 receive it. tun takes ipv6 address from env TunIPv6,
+		// and routes ${CIDR6} to it, so replies of ipv6 go back through tun too
 		Args: []string{`
 sysctl -w net.ipv4.ip_forward=1
 sysctl -w net.ipv6.conf.all.disable_ipv6=0
@@ -46,19 +48,14 @@ ip6tables -P INPUT ACCEPT
 iptables -P FORWARD ACCEPT
 ip6tables -P FORWARD ACCEPT
 iptables -t nat -A PREROUTING ! -p icmp ! -s 127.0.0.1 ! -d ${CIDR4} -j DNAT --to 127.0.0.1:15006
-ip6tables -t nat -A PREROUTING ! -p icmp ! -s 0:0:0:0:0:0:0:1 ! -d ${CIDR6} -j DNAT --to [0:0:0:0:0:0:0:1]:15006
+ip6tables -t nat -A PREROUTING -p tcp ! -s 0:0:0:0:0:0:0:1 ! -d ${CIDR6} -j REDIRECT --to-ports 15006
+ip6tables -t nat -A PREROUTING -p udp ! -s 0:0:0:0:0:0:0:1 ! -d ${CIDR6} -j REDIRECT --to-ports 15006
 iptables -t nat -A POSTROUTING ! -p icmp ! -s 127.0.0.1 ! -d ${CIDR4} -j MASQUERADE
-ip6tables -t nat -A POSTROUTING ! -p icmp ! -s 0:0:0:0:0:0:0:1 ! -d ${CIDR6} -j MASQUERADE
-kubevpn serve -L "tun:/localhost:8422?net=${TunIPv4}&route=${CIDR4}" -F "tcp://${TrafficManagerService}:10800"`,
+ip6tables -t nat -A POSTROUTING ! -p ipv6-icmp ! -s 0:0:0:0:0:0:0:1 ! -d ${CIDR6} -j MASQUERADE
+kubevpn serve -L "tun:/localhost:8422?net=${TunIPv4}&route=${CIDR4},${CIDR6}" -F "tcp://${TrafficManagerService}:10800"`,
 		},
-		EnvFrom: []v1.EnvFromSource{{
-			SecretRef: &v1.SecretEnvSource{
-				LocalObjectReference: v1.LocalObjectReference{
-					Name: config.ConfigMapPodTrafficManager,
-				},
-			},
-		}},
 		Env: []v1.EnvVar{
+			util.EnvFromTrafficManagerSecret(config.TLSCertKey),
 			{
 				Name:  "CIDR4",
 				Value: config.CIDR.String(),
@@ -75,6 +72,10 @@ kubevpn serve -L "tun:/localhost:8422?net=${TunIPv4}&route=${CIDR4}" -F "tcp://$
 				Name:  config.EnvInboundPodTunIPv6,
 				Value: "",
 			},
+			{
+				Name:  config.EnvTunnelKey,
+				Value: "",
+			},
 			{
 				Name:  "TrafficManagerService",
 				Value: config.ConfigMapPodTrafficManager,
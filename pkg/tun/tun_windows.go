@@ -16,6 +16,8 @@ import (
 	wintun "golang.zx2c4.com/wintun"
 	wireguardtun "golang.zx2c4.com/wireguard/tun"
 	"golang.zx2c4.com/wireguard/windows/tunnel/winipcfg"
+
+	"github.com/wencaiwulue/kubevpn/pkg/config"
 )
 
 func createTun(cfg Config) (conn net.Conn, itf *net.Interface, err error) {
@@ -28,7 +30,11 @@ func createTun(cfg Config) (conn net.Conn, itf *net.Interface, err error) {
 	if len(cfg.Name) != 0 {
 		interfaceName = cfg.Name
 	}
-	tunDevice, err := wireguardtun.CreateTUN(interfaceName, cfg.MTU)
+	mtu := cfg.MTU
+	if mtu <= 0 {
+		mtu = config.DefaultMTU
+	}
+	tunDevice, err := wireguardtun.CreateTUN(interfaceName, mtu)
 	if err != nil {
 		err = fmt.Errorf("failed to create TUN device: %w", err)
 		return
@@ -63,6 +69,10 @@ func createTun(cfg Config) (conn net.Conn, itf *net.Interface, err error) {
 		}
 	}
 
+	if err = setTunMTU(ifName, mtu, cfg.Addr != "", cfg.Addr6 != ""); err != nil {
+		return
+	}
+
 	var tunName string
 	tunName, err = tunDevice.Name()
 	if err != nil {
@@ -86,6 +96,28 @@ func createTun(cfg Config) (conn net.Conn, itf *net.Interface, err error) {
 	return
 }
 
+// setTunMTU wintun ignores mtu when creating device, needs to set it on ip interface
+func setTunMTU(luid winipcfg.LUID, mtu int, ipv4, ipv6 bool) error {
+	var families []winipcfg.AddressFamily
+	if ipv4 {
+		families = append(families, windows.AF_INET)
+	}
+	if ipv6 {
+		families = append(families, windows.AF_INET6)
+	}
+	for _, family := range families {
+		ipInterface, err := luid.IPInterface(family)
+		if err != nil {
+			return err
+		}
+		ipInterface.NLMTU = uint32(mtu)
+		if err = ipInterface.Set(); err != nil {
+			return fmt.Errorf("can not setup mtu %d to device: %v", mtu, err)
+		}
+	}
+	return nil
+}
+
 func addTunRoutes(tunName string, routes ...types.Route) error {
 	name, err2 := net.InterfaceByName(tunName)
 	if err2 != nil {
@@ -103,6 +103,20 @@ func max[T constraints.Ordered](a T, b T) T {
 	return b
 }
 
+// EnvFromTrafficManagerSecret env of key in secret of traffic manager, secret also saves tunnel keys of clients,
+// so only mount keys which container needs, not the whole secret
+func EnvFromTrafficManagerSecret(key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: key,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: config.ConfigMapPodTrafficManager},
+				Key:                  key,
+			},
+		},
+	}
+}
+
 func GetEnv(ctx context.Context, f util.Factory, ns, pod string) (map[string][]string, error) {
 	set, err2 := f.KubernetesClientSet()
 	if err2 != nil {
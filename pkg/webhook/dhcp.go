@@ -2,13 +2,16 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/cmd/util/podcmd"
 
 	"github.com/wencaiwulue/kubevpn/pkg/config"
 	"github.com/wencaiwulue/kubevpn/pkg/handler"
@@ -17,6 +20,8 @@ import (
 type dhcpServer struct {
 	f         util.Factory
 	clientset *kubernetes.Clientset
+	// namespace of traffic manager
+	namespace string
 }
 
 func (d *dhcpServer) rentIP(w http.ResponseWriter, r *http.Request) {
@@ -25,17 +30,28 @@ func (d *dhcpServer) rentIP(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
 	log.Infof("handling rent ip request, pod name: %s, ns: %s", podName, namespace)
-	cmi := d.clientset.CoreV1().ConfigMaps(namespace)
-	dhcp := handler.NewDHCPManager(cmi, namespace)
+	if err := d.authenticate(ctx, r, podName, namespace); err != nil {
+		log.Errorf("reject rent ip request, err: %v", err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	dhcp := handler.NewDHCPManager(d.clientset.CoreV1().ConfigMaps(namespace), d.clientset.CoreV1().Secrets(namespace), namespace)
 	v4, v6, err := dhcp.RentIPRandom(ctx)
 	if err != nil {
 		log.Errorf("rent ip failed, err: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	// sidecar lives with pod, so key is valid until ip is released
+	token, err := dhcp.IssueTunnelKey(ctx, v4.IP, v6.IP, 0)
+	if err != nil {
+		log.Errorf("issue tunnel key failed, err: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 	// todo patch annotation
-	_, err = w.Write([]byte(fmt.Sprintf("%s,%s", v4.String(), v6.String())))
+	_, err = w.Write([]byte(fmt.Sprintf("%s,%s,%s", v4.String(), v6.String(), token)))
 	if err != nil {
 		log.Errorf("write response failed, err: %v", err)
 	}
@@ -44,6 +60,7 @@ func (d *dhcpServer) rentIP(w http.ResponseWriter, r *http.Request) {
 func (d *dhcpServer) releaseIP(w http.ResponseWriter, r *http.Request) {
 	podName := r.Header.Get(config.HeaderPodName)
 	namespace := r.Header.Get(config.HeaderPodNamespace)
+	ctx := context.Background()
 
 	var ips []net.IP
 	for _, s := range []string{r.Header.Get(config.HeaderIPv4), r.Header.Get(config.HeaderIPv6)} {
@@ -56,12 +73,91 @@ func (d *dhcpServer) releaseIP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Infof("handling release ip request, pod name: %s, ns: %s", podName, namespace)
-	cmi := d.clientset.CoreV1().ConfigMaps(namespace)
-	dhcp := handler.NewDHCPManager(cmi, namespace)
-	if err := dhcp.ReleaseIP(context.Background(), ips...); err != nil {
+	if err := d.authenticate(ctx, r, podName, namespace); err != nil {
+		log.Errorf("reject release ip request, err: %v", err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	dhcp := handler.NewDHCPManager(d.clientset.CoreV1().ConfigMaps(namespace), d.clientset.CoreV1().Secrets(namespace), namespace)
+	if err := dhcp.ReleaseIP(ctx, ips...); err != nil {
 		log.Errorf("release ip failed, err: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
+
+// authenticate request is sent by vpn sidecar of pod, remote address of request must be ip of pod
+func (d *dhcpServer) authenticate(ctx context.Context, r *http.Request, podName, namespace string) error {
+	pod, err := d.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if container, _ := podcmd.FindContainerByName(pod, config.ContainerSidecarVPN); container == nil {
+		return fmt.Errorf("pod %s has no container %s", podName, config.ContainerSidecarVPN)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return err
+	}
+	for _, podIP := range pod.Status.PodIPs {
+		if net.ParseIP(podIP.IP).Equal(net.ParseIP(host)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("remote address %s is not ip of pod %s", host, podName)
+}
+
+// tunnelKey issue, renew or revoke tunnel key of client, client reach it through service proxy of api server,
+// it proves it can write configmap by claim when issuing, and proves it owns the key when renewing or revoking
+func (d *dhcpServer) tunnelKey(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	ipv4, _, err := net.ParseCIDR(r.Header.Get(config.HeaderIPv4))
+	if err != nil {
+		log.Errorf("ip is invailed, err: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	dhcp := handler.NewDHCPManager(d.clientset.CoreV1().ConfigMaps(d.namespace), d.clientset.CoreV1().Secrets(d.namespace), d.namespace)
+	switch r.Method {
+	case http.MethodPost:
+		var ipv6 net.IP
+		ipv6, _, err = net.ParseCIDR(r.Header.Get(config.HeaderIPv6))
+		if err != nil {
+			log.Errorf("ip is invailed, err: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err = dhcp.VerifyTunnelKeyClaim(ctx, ipv4, r.Header.Get(config.HeaderTunnelKeyClaim)); err != nil {
+			log.Errorf("reject issuing tunnel key, err: %v", err)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var token string
+		token, err = dhcp.IssueTunnelKey(ctx, ipv4, ipv6, handler.TunnelKeyLifetime)
+		if err != nil {
+			log.Errorf("issue tunnel key failed, err: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		log.Infof("issue tunnel key to %s", ipv4.String())
+		if _, err = w.Write([]byte(token)); err != nil {
+			log.Errorf("write response failed, err: %v", err)
+		}
+		return
+	case http.MethodPut:
+		err = dhcp.RenewTunnelKey(ctx, ipv4, r.Header.Get(config.HeaderTunnelKeyProof), handler.TunnelKeyLifetime)
+	case http.MethodDelete:
+		err = dhcp.RevokeTunnelKey(ctx, ipv4, r.Header.Get(config.HeaderTunnelKeyProof))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if errors.Is(err, handler.ErrTunnelKeyNotFound) {
+		log.Infof("tunnel key of %s is expired, client needs to request a new one", ipv4.String())
+		w.WriteHeader(http.StatusNotFound)
+	} else if err != nil {
+		log.Errorf("handle %s tunnel key of %s failed, err: %v", r.Method, ipv4.String(), err)
+		w.WriteHeader(http.StatusForbidden)
+	}
+}
@@ -133,9 +133,14 @@ func Main(f cmdutil.Factory) error {
 	http.HandleFunc("/pods", func(w http.ResponseWriter, r *http.Request) { serve(w, r, newDelegateToV1AdmitHandler(h.admitPods)) })
 	http.HandleFunc("/readyz", func(w http.ResponseWriter, req *http.Request) { _, _ = w.Write([]byte("ok")) })
 
-	s := &dhcpServer{f: f, clientset: clientset}
+	namespace, _, err := f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	s := &dhcpServer{f: f, clientset: clientset, namespace: namespace}
 	http.HandleFunc(config.APIRentIP, s.rentIP)
 	http.HandleFunc(config.APIReleaseIP, s.releaseIP)
+	http.HandleFunc(config.APITunnelKey, s.tunnelKey)
 
 	var pairs []tls.Certificate
 	pairs, err = getSSLKeyPairs()
@@ -58,7 +58,8 @@ func (h *admissionReviewHandler) admitPods(ar v1.AdmissionReview) *v1.AdmissionR
 						}
 						found = true
 						cmi := h.clientset.CoreV1().ConfigMaps(ar.Request.Namespace)
-						dhcp := handler.NewDHCPManager(cmi, ar.Request.Namespace)
+						secreti := h.clientset.CoreV1().Secrets(ar.Request.Namespace)
+						dhcp := handler.NewDHCPManager(cmi, secreti, ar.Request.Namespace)
 						// remove old values
 						if pair.Value != "" {
 							var ips []net.IP
@@ -77,6 +78,11 @@ func (h *admissionReviewHandler) admitPods(ar v1.AdmissionReview) *v1.AdmissionR
 							log.Errorf("rent ip random failed, err: %v", err)
 							return toV1AdmissionResponse(err)
 						}
+						_, err = dhcp.IssueTunnelKey(context.Background(), v4.IP, v6.IP, 0)
+						if err != nil {
+							log.Errorf("issue tunnel key failed, err: %v", err)
+							return toV1AdmissionResponse(err)
+						}
 						var name string
 						if accessor, errT := meta.Accessor(ar.Request.Object); errT == nil {
 							name = accessor.GetName()
@@ -92,6 +98,16 @@ func (h *admissionReviewHandler) admitPods(ar v1.AdmissionReview) *v1.AdmissionR
 					if pair.Name == config.EnvInboundPodTunIPv6 && v6 != nil {
 						pod.Spec.Containers[i].Env[j].Value = v6.String()
 					}
+					// mount tunnel key from secret, not save it in pod spec
+					if pair.Name == config.EnvTunnelKey && v4 != nil {
+						pod.Spec.Containers[i].Env[j].Value = ""
+						pod.Spec.Containers[i].Env[j].ValueFrom = &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: config.ConfigMapPodTrafficManager},
+								Key:                  handler.TunnelKeyName(v4.IP.String()),
+							},
+						}
+					}
 				}
 			}
 		}
@@ -151,7 +167,8 @@ func (h *admissionReviewHandler) admitPods(ar v1.AdmissionReview) *v1.AdmissionR
 			}
 			if len(ips) != 0 {
 				cmi := h.clientset.CoreV1().ConfigMaps(ar.Request.Namespace)
-				err := handler.NewDHCPManager(cmi, ar.Request.Namespace).
+				secreti := h.clientset.CoreV1().Secrets(ar.Request.Namespace)
+				err := handler.NewDHCPManager(cmi, secreti, ar.Request.Namespace).
 					ReleaseIP(context.Background(), ips...)
 				if err != nil {
 					log.Errorf("release ip to dhcp err: %v, ips: %v", err, ips)